@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"time"
+)
+
+// heavyOpWait is how long a heavy operation queues for a free slot
+// before giving up with errDriveBusy so the caller backs off and
+// retries. Declared as a variable so tests can shorten it.
+var heavyOpWait = time.Second
+
+// driveHeavyGate bounds how many expensive operations (ReadMultiple,
+// VerifyFile, NSScanner) run concurrently against one drive, so a
+// misbehaving peer cannot starve legitimate I/O. Cheap metadata ops
+// are deliberately not gated to keep control-plane traffic responsive.
+type driveHeavyGate struct {
+	slots chan struct{}
+}
+
+func newDriveHeavyGate(slots int) *driveHeavyGate {
+	return &driveHeavyGate{slots: make(chan struct{}, slots)}
+}
+
+// acquire takes a slot, queueing up to heavyOpWait before failing with
+// errDriveBusy. Every successful acquire must be paired with release.
+func (g *driveHeavyGate) acquire(ctx context.Context) error {
+	select {
+	case g.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	timer := time.NewTimer(heavyOpWait)
+	defer timer.Stop()
+	select {
+	case g.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return errDriveBusy
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g *driveHeavyGate) release() {
+	<-g.slots
+}
+
+// defaultHeavyOpLimit scales the heavy operations limit to how many
+// drives share this node: more drives per node means fewer slots per
+// drive, but never fewer than four.
+func defaultHeavyOpLimit(drives int) int {
+	if drives < 1 {
+		drives = 1
+	}
+	limit := 32 / drives
+	if limit < 4 {
+		limit = 4
+	}
+	return limit
+}
+
+// The limit comes from the drive config, or a default scaled to the
+// drive count; it is captured when the drive serves its first heavy
+// operation.
+var globalDriveHeavyGates = newDriveRegistry(func() *driveHeavyGate {
+	limit := globalDriveConfig.GetMaxHeavyOps()
+	if limit <= 0 {
+		globalLocalDrivesMu.RLock()
+		drives := len(globalLocalDrivesMap)
+		globalLocalDrivesMu.RUnlock()
+		limit = defaultHeavyOpLimit(drives)
+	}
+	return newDriveHeavyGate(limit)
+})
+
+// driveHeavyGateFor returns the heavy operations gate for the given
+// endpoint, creating it on first use.
+func driveHeavyGateFor(endpoint string) *driveHeavyGate {
+	return globalDriveHeavyGates.get(endpoint)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "sync"
+
+// driveRegistry tracks one value per local drive endpoint. Entries are
+// created on first use and never removed, so per-drive state survives
+// storage re-initialization of the same drive. It backs the various
+// per-drive trackers (gates, budgets, windows, logs) that must outlive
+// any single xlStorage instance.
+type driveRegistry[T any] struct {
+	mu      sync.Mutex
+	entries map[string]*T
+	newFn   func() *T
+}
+
+// newDriveRegistry returns a registry creating missing entries with newFn.
+func newDriveRegistry[T any](newFn func() *T) *driveRegistry[T] {
+	return &driveRegistry[T]{
+		entries: make(map[string]*T),
+		newFn:   newFn,
+	}
+}
+
+// get returns the entry for the given endpoint, creating it on first use.
+func (r *driveRegistry[T]) get(endpoint string) *T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.entries[endpoint]
+	if !ok {
+		v = r.newFn()
+		r.entries[endpoint] = v
+	}
+	return v
+}
+
+// all returns a snapshot of the registered entries keyed by endpoint.
+func (r *driveRegistry[T]) all() map[string]*T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make(map[string]*T, len(r.entries))
+	for endpoint, v := range r.entries {
+		out[endpoint] = v
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "sync"
+
+// Background task types tracked per drive.
+const (
+	taskQueueScanner = "scanner"
+	taskQueueHeal    = "heal"
+	taskQueueCleanup = "cleanup"
+)
+
+// driveTaskQueue tracks how many background tasks of each type are
+// queued or running against one drive. Subsystems call enqueue when a
+// task is submitted and dequeue when it finishes, so the depth shows
+// how far the drive is behind.
+type driveTaskQueue struct {
+	mu     sync.Mutex
+	depths map[string]int64
+}
+
+func (q *driveTaskQueue) enqueue(task string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.depths[task]++
+}
+
+func (q *driveTaskQueue) dequeue(task string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.depths[task] > 0 {
+		q.depths[task]--
+	}
+}
+
+// snapshot returns a copy of the current per-task depths.
+func (q *driveTaskQueue) snapshot() map[string]int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	depths := make(map[string]int64, len(q.depths))
+	for task, depth := range q.depths {
+		depths[task] = depth
+	}
+	return depths
+}
+
+var globalDriveTaskQueues = newDriveRegistry(func() *driveTaskQueue {
+	return &driveTaskQueue{depths: make(map[string]int64)}
+})
+
+// driveTaskQueueFor returns the task queue tracker for the given
+// endpoint, creating it on first use.
+func driveTaskQueueFor(endpoint string) *driveTaskQueue {
+	return globalDriveTaskQueues.get(endpoint)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// driveWriteSlots is how many write handler calls one drive serves
+// concurrently before further writers queue. Declared as a variable so
+// tests can tighten it.
+var driveWriteSlots = 16
+
+// writeDelayStat accumulates, for one write handler, how long its
+// calls spent queued for a write slot versus in the storage call
+// itself, so saturation can be told apart from slow media.
+type writeDelayStat struct {
+	Count      int64
+	QueueNanos int64
+	IONanos    int64
+}
+
+// driveWriteGate bounds concurrent writes to one drive and records the
+// queueing/IO split per handler.
+type driveWriteGate struct {
+	slots chan struct{}
+
+	mu    sync.Mutex
+	stats map[string]*writeDelayStat
+}
+
+func newDriveWriteGate(slots int) *driveWriteGate {
+	return &driveWriteGate{
+		slots: make(chan struct{}, slots),
+		stats: make(map[string]*writeDelayStat),
+	}
+}
+
+func (g *driveWriteGate) record(handler string, queued, io time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	st, ok := g.stats[handler]
+	if !ok {
+		st = &writeDelayStat{}
+		g.stats[handler] = st
+	}
+	st.Count++
+	st.QueueNanos += queued.Nanoseconds()
+	st.IONanos += io.Nanoseconds()
+}
+
+// snapshot returns a copy of the accumulated per-handler delays.
+func (g *driveWriteGate) snapshot() map[string]writeDelayStat {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	stats := make(map[string]writeDelayStat, len(g.stats))
+	for handler, st := range g.stats {
+		stats[handler] = *st
+	}
+	return stats
+}
+
+// run executes fn while holding one of the drive's write slots,
+// recording the time spent waiting for the slot separately from the
+// time fn itself took.
+func (g *driveWriteGate) run(ctx context.Context, handler string, fn func() error) error {
+	start := time.Now()
+	select {
+	case g.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	queued := time.Since(start)
+	ioStart := time.Now()
+	err := fn()
+	io := time.Since(ioStart)
+	<-g.slots
+	g.record(handler, queued, io)
+	return err
+}
+
+// lifeTimeOps flattens the accumulated delays into counters suitable
+// for madmin.DiskMetric.LifeTimeOps, prefixed "writegate." so they do
+// not collide with the regular API counters.
+func (g *driveWriteGate) lifeTimeOps() map[string]uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.stats) == 0 {
+		return nil
+	}
+	ops := make(map[string]uint64, len(g.stats)*3)
+	for handler, st := range g.stats {
+		ops["writegate."+handler+".count"] = uint64(st.Count)
+		ops["writegate."+handler+".queue_ns"] = uint64(st.QueueNanos)
+		ops["writegate."+handler+".io_ns"] = uint64(st.IONanos)
+	}
+	return ops
+}
+
+var globalDriveWriteGates = newDriveRegistry(func() *driveWriteGate {
+	return newDriveWriteGate(driveWriteSlots)
+})
+
+// driveWriteGateFor returns the write gate for the given endpoint,
+// creating it on first use.
+func driveWriteGateFor(endpoint string) *driveWriteGate {
+	return globalDriveWriteGates.get(endpoint)
+}
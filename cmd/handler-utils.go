@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/textproto"
+	"path"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/minio/madmin-go/v3"
 	"github.com/minio/minio/internal/auth"
@@ -299,17 +301,32 @@ func trimAwsChunkedContentEncoding(contentEnc string) (trimmedContentEnc string)
 	return strings.Join(newEncs, ",")
 }
 
-func collectInternodeStats(f http.HandlerFunc) http.HandlerFunc {
+// collectInternodeStats updates the aggregate internode traffic
+// counters and, when endpoint identifies a disk, the per-method
+// request, error and latency counters in globalInternodeHandlerStats.
+// The method name is the last element of the route path.
+func collectInternodeStats(endpoint string, f http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		f.ServeHTTP(w, r)
+		startTime := time.Now()
 
 		tc, ok := r.Context().Value(mcontext.ContextTraceKey).(*mcontext.TraceCtxt)
 		if !ok || tc == nil {
+			// No trace context; wrap the writer so the status
+			// code is still observed for the error counter.
+			rec := xhttp.NewResponseRecorder(w)
+			f.ServeHTTP(rec, r)
+			failed := rec.StatusCode >= http.StatusBadRequest
+			globalInternodeHandlerStats.record(endpoint, path.Base(r.URL.Path), time.Since(startTime), failed)
 			return
 		}
 
+		f.ServeHTTP(w, r)
+
 		globalConnStats.incInternodeInputBytes(int64(tc.RequestRecorder.Size()))
 		globalConnStats.incInternodeOutputBytes(int64(tc.ResponseRecorder.Size()))
+
+		failed := tc.ResponseRecorder.StatusCode >= http.StatusBadRequest
+		globalInternodeHandlerStats.record(endpoint, path.Base(r.URL.Path), time.Since(startTime), failed)
 	}
 }
 
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"math/rand"
 	"net"
 	"net/http"
 	"reflect"
@@ -26,6 +27,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/minio/madmin-go/v3"
@@ -195,6 +197,58 @@ func httpTraceAll(f http.HandlerFunc) http.HandlerFunc {
 	return httpTrace(f, true)
 }
 
+// traceSampler decides cheaply per request whether it should be
+// traced, at a configured percentage rate. The default rate of 100
+// traces every request.
+type traceSampler struct {
+	rate int64 // percent of requests to trace, accessed atomically
+}
+
+func (t *traceSampler) setRate(pct int64) {
+	atomic.StoreInt64(&t.rate, pct)
+}
+
+func (t *traceSampler) getRate() int64 {
+	return atomic.LoadInt64(&t.rate)
+}
+
+// sample reports whether the current request should be traced.
+func (t *traceSampler) sample() bool {
+	rate := atomic.LoadInt64(&t.rate)
+	if rate >= 100 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	return rand.Uint32()%100 < uint32(rate)
+}
+
+var globalTraceSamplers = newDriveRegistry(func() *traceSampler {
+	return &traceSampler{rate: 100}
+})
+
+// traceSamplerFor returns the trace sampler for the given endpoint,
+// creating it with the default rate on first use.
+func traceSamplerFor(endpoint string) *traceSampler {
+	return globalTraceSamplers.get(endpoint)
+}
+
+// httpTraceSampledHdrs traces request headers for only the endpoint's
+// configured percentage of requests; sampled-out requests run the
+// handler without marking it for tracing.
+func httpTraceSampledHdrs(endpoint string, f http.HandlerFunc) http.HandlerFunc {
+	sampler := traceSamplerFor(endpoint)
+	traced := httpTraceHdrs(f)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if sampler.sample() {
+			traced(w, r)
+			return
+		}
+		f(w, r)
+	}
+}
+
 func httpTraceHdrs(f http.HandlerFunc) http.HandlerFunc {
 	return httpTrace(f, false)
 }
@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/grid"
+)
+
+// internodeLatencyBuckets are the upper bounds of the per-method
+// latency histogram, in increasing order. Observations above the last
+// bound land in a final overflow bucket.
+var internodeLatencyBuckets = [...]time.Duration{
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+}
+
+// internodeMethodStats holds counters for one storage REST method on
+// one disk endpoint.
+type internodeMethodStats struct {
+	Requests uint64
+	Errors   uint64
+	// Latency counts one entry per bucket in
+	// internodeLatencyBuckets, plus a final overflow bucket.
+	Latency [len(internodeLatencyBuckets) + 1]uint64
+}
+
+// internodeHandlerStats aggregates per-method request, error and
+// latency counters for internode storage calls, keyed by disk
+// endpoint so slowness can be attributed to a physical drive.
+type internodeHandlerStats struct {
+	mu    sync.Mutex
+	disks map[string]map[string]*internodeMethodStats
+}
+
+func (s *internodeHandlerStats) record(endpoint, method string, d time.Duration, failed bool) {
+	if endpoint == "" || method == "" {
+		return
+	}
+	bucket := len(internodeLatencyBuckets)
+	for i, b := range internodeLatencyBuckets {
+		if d <= b {
+			bucket = i
+			break
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	methods := s.disks[endpoint]
+	if methods == nil {
+		methods = make(map[string]*internodeMethodStats)
+		s.disks[endpoint] = methods
+	}
+	st := methods[method]
+	if st == nil {
+		st = &internodeMethodStats{}
+		methods[method] = st
+	}
+	st.Requests++
+	if failed {
+		st.Errors++
+	}
+	st.Latency[bucket]++
+}
+
+// snapshot returns a copy of all counters, keyed by disk endpoint and
+// method.
+func (s *internodeHandlerStats) snapshot() map[string]map[string]internodeMethodStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]map[string]internodeMethodStats, len(s.disks))
+	for endpoint, methods := range s.disks {
+		m := make(map[string]internodeMethodStats, len(methods))
+		for method, st := range methods {
+			m[method] = *st
+		}
+		out[endpoint] = m
+	}
+	return out
+}
+
+// lifeTimeOps flattens the counters for one disk endpoint into the
+// key space of madmin.DiskMetric.LifeTimeOps, using an "internode."
+// prefix so they do not collide with the storage API counters.
+func (s *internodeHandlerStats) lifeTimeOps(endpoint string) map[string]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	methods := s.disks[endpoint]
+	if len(methods) == 0 {
+		return nil
+	}
+	ops := make(map[string]uint64, len(methods)*3)
+	for method, st := range methods {
+		ops["internode."+method] = st.Requests
+		if st.Errors > 0 {
+			ops["internode."+method+".errors"] = st.Errors
+		}
+		for i, n := range st.Latency {
+			if n == 0 {
+				continue
+			}
+			le := "inf"
+			if i < len(internodeLatencyBuckets) {
+				le = internodeLatencyBuckets[i].String()
+			}
+			ops["internode."+method+".le."+le] = n
+		}
+	}
+	return ops
+}
+
+var globalInternodeHandlerStats = &internodeHandlerStats{
+	disks: make(map[string]map[string]*internodeMethodStats),
+}
+
+// gridInstrument wraps a grid single-handler so its calls are counted
+// in globalInternodeHandlerStats, mirroring what collectInternodeStats
+// does for HTTP handlers. Grid handlers bypass the HTTP wrappers
+// entirely, so they need their own hook.
+func gridInstrument[Req, Resp any](endpoint, method string, handle func(req Req) (Resp, *grid.RemoteErr)) func(req Req) (Resp, *grid.RemoteErr) {
+	return func(req Req) (Resp, *grid.RemoteErr) {
+		start := time.Now()
+		resp, err := handle(req)
+		globalInternodeHandlerStats.record(endpoint, method, time.Since(start), err != nil)
+		return resp, err
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io"
+
+	"github.com/dustin/go-humanize"
+)
+
+// merkleTreeDefaultLeafSize is used when the caller does not specify a
+// leaf size. A 1MiB leaf keeps the tree small even for large parts
+// while still localizing corruption to a single megabyte.
+const merkleTreeDefaultLeafSize = 1 * humanize.MiByte
+
+// merkleTreeCacheSuffix is appended to the file path when a computed
+// tree is cached next to the file it describes.
+const merkleTreeCacheSuffix = ".mtree"
+
+// computeMerkleTree hashes the contents of r in leafSize chunks and
+// folds the leaf hashes into a merkle root. The leaf hashes are
+// retained so a caller can compare trees and re-read only the
+// corrupted leaves.
+func computeMerkleTree(r io.Reader, leafSize int64) (MerkleTreeInfo, error) {
+	mt := MerkleTreeInfo{LeafSize: leafSize}
+	h := HighwayHash256.New()
+	buf := make([]byte, leafSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			h.Reset()
+			h.Write(buf[:n])
+			mt.Leaves = append(mt.Leaves, h.Sum(nil))
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return mt, err
+		}
+	}
+	mt.Root = merkleRoot(mt.Leaves)
+	return mt, nil
+}
+
+// merkleRoot folds a level of hashes pairwise until a single root
+// remains. An odd node is carried up unmodified, following the
+// common "promote the last node" construction.
+func merkleRoot(level [][]byte) []byte {
+	if len(level) == 0 {
+		return nil
+	}
+	h := HighwayHash256.New()
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			h.Reset()
+			h.Write(level[i])
+			h.Write(level[i+1])
+			next = append(next, h.Sum(nil))
+		}
+		level = next
+	}
+	return level[0]
+}
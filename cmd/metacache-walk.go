@@ -19,9 +19,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/minio/minio/internal/grid"
 	xioutil "github.com/minio/minio/internal/ioutil"
@@ -30,6 +32,10 @@ import (
 
 //go:generate msgp -file $GOFILE
 
+// errSnapshotSkip indicates an entry was written after the listing
+// snapshot was taken and must be excluded from the stream.
+var errSnapshotSkip = errors.New("entry newer than listing snapshot")
+
 // WalkDirOptions provides options for WalkDir operations.
 type WalkDirOptions struct {
 	// Bucket to scanner
@@ -54,6 +60,13 @@ type WalkDirOptions struct {
 	// Limit the number of returned objects if > 0.
 	Limit int
 
+	// Snapshot will record the walk start time and exclude entries
+	// whose metadata was written after it, so the streamed listing
+	// reflects a single point in time. Nothing extra is buffered -
+	// memory stays flat - but concurrent deletions can still be
+	// observed, only additions and overwrites are ignored.
+	Snapshot bool
+
 	// DiskID contains the disk ID of the disk.
 	// Leave empty to not check disk ID.
 	DiskID string
@@ -88,6 +101,23 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 		}
 	}
 
+	// When a snapshot is requested, record the walk start time, entries
+	// whose metadata was written after this point are excluded below.
+	var snapTime time.Time
+	if opts.Snapshot {
+		snapTime = UTCNow()
+	}
+	readMeta := func(ctx context.Context, itemPath string) ([]byte, error) {
+		if snapTime.IsZero() {
+			return s.readMetadata(ctx, itemPath)
+		}
+		buf, dmTime, err := s.readMetadataWithDMTime(ctx, itemPath)
+		if err == nil && dmTime.After(snapTime) {
+			return nil, errSnapshotSkip
+		}
+		return buf, err
+	}
+
 	// Use a small block size to start sending quickly
 	w := newMetacacheWriter(wr, 16<<10)
 	w.reuseBlocks = true // We are not sharing results, so reuse buffers.
@@ -120,7 +150,7 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 	// Fast exit track to check if we are listing an object with
 	// a trailing slash, this will avoid to list the object content.
 	if HasSuffix(opts.BaseDir, SlashSeparator) {
-		metadata, err := s.readMetadata(ctx, pathJoin(volumeDir,
+		metadata, err := readMeta(ctx, pathJoin(volumeDir,
 			opts.BaseDir[:len(opts.BaseDir)-1]+globalDirSuffix,
 			xlStorageFormatFile))
 		diskHealthCheckOK(ctx, err)
@@ -236,7 +266,7 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 				if s.walkReadMu != nil {
 					s.walkReadMu.Lock()
 				}
-				meta.metadata, err = s.readMetadata(ctx, pathJoinBuf(sb, volumeDir, current, entry))
+				meta.metadata, err = readMeta(ctx, pathJoinBuf(sb, volumeDir, current, entry))
 				if s.walkReadMu != nil {
 					s.walkReadMu.Unlock()
 				}
@@ -245,7 +275,7 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 					// It is totally possible that xl.meta was overwritten
 					// while being concurrently listed at the same time in
 					// such scenarios the 'xl.meta' might get truncated
-					if !IsErrIgnored(err, io.EOF, io.ErrUnexpectedEOF) {
+					if !IsErrIgnored(err, io.EOF, io.ErrUnexpectedEOF, errSnapshotSkip) {
 						internalLogOnceIf(ctx, err, "metacache-walk-read-metadata")
 					}
 					continue
@@ -331,7 +361,7 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 			if s.walkReadMu != nil {
 				s.walkReadMu.Lock()
 			}
-			meta.metadata, err = s.readMetadata(ctx, pathJoinBuf(sb, volumeDir, meta.name, xlStorageFormatFile))
+			meta.metadata, err = readMeta(ctx, pathJoinBuf(sb, volumeDir, meta.name, xlStorageFormatFile))
 			if s.walkReadMu != nil {
 				s.walkReadMu.Unlock()
 			}
@@ -365,6 +395,8 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 						dirStack = append(dirStack, meta.name+slashSeparator)
 					}
 				}
+			case errors.Is(err, errSnapshotSkip):
+				// Written after the snapshot was taken, skip.
 			case isSysErrNotDir(err):
 				// skip
 			}
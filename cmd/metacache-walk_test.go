@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWalkDirSnapshot(t *testing.T) {
+	storage, diskPath, err := newXLStorageTestSetup(t)
+	if err != nil {
+		t.Fatalf("Unable to create xlStorage test setup, %s", err)
+	}
+	ctx := context.Background()
+
+	volume := "snapshot-vol"
+	if err := storage.MakeVol(ctx, volume); err != nil {
+		t.Fatalf("Unable to create volume, %s", err)
+	}
+
+	writeObj := func(name string) {
+		t.Helper()
+		fi := FileInfo{
+			Name: name, Volume: volume, ModTime: UTCNow(), Size: 10,
+			Erasure: ErasureInfo{
+				Algorithm:    erasureAlgorithm,
+				DataBlocks:   4,
+				ParityBlocks: 4,
+				BlockSize:    blockSizeV2,
+				Index:        1,
+				Distribution: []int{0, 1, 2, 3, 4, 5, 6, 7},
+			},
+		}
+		if err := storage.WriteMetadata(ctx, "", volume, name, fi); err != nil {
+			t.Fatalf("Unable to create object, %s", err)
+		}
+	}
+	writeObj("obj-live")
+	writeObj("obj-future")
+
+	// Simulate a write landing after the snapshot is taken by pushing
+	// the metadata modtime past the walk start time.
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(pathJoin(diskPath, volume, "obj-future", xlStorageFormatFile), future, future); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	list := func(snapshot bool) []string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := storage.WalkDir(ctx, WalkDirOptions{Bucket: volume, Recursive: true, Snapshot: snapshot}, &buf); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		mr := newMetacacheReader(&buf)
+		defer mr.Close()
+		var names []string
+		for {
+			entry, err := mr.next()
+			if err != nil {
+				break
+			}
+			names = append(names, entry.name)
+		}
+		return names
+	}
+
+	if names := list(false); len(names) != 2 {
+		t.Fatalf("expected both objects in a plain walk, got %v", names)
+	}
+	if names := list(true); len(names) != 1 || names[0] != "obj-live" {
+		t.Fatalf("expected snapshot walk to exclude the concurrent write, got %v", names)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+
+	"github.com/zeebo/xxh3"
+)
+
+// metaDigestScan accumulates the rolling metadata digest of one
+// scanner pass. The digest is the xor of per-object hashes over the
+// metadata path and content, so it is independent of scan order.
+type metaDigestScan struct {
+	digest  uint64
+	objects uint64
+}
+
+func (m *metaDigestScan) fold(path string, meta []byte) {
+	h := xxh3.New()
+	h.WriteString(path)
+	h.Write([]byte{0})
+	h.Write(meta)
+	m.digest ^= h.Sum64()
+	m.objects++
+}
+
+// driveMetaDigest combines the digests published by the drive's last
+// completed scanner pass over each bucket, so consistency checks read
+// one value instead of walking metadata. Scans run per bucket, hence
+// the digest is kept per bucket and xored together on read.
+type driveMetaDigest struct {
+	mu      sync.Mutex
+	buckets map[string]MetadataDigest
+}
+
+func (d *driveMetaDigest) publish(bucket string, scan *metaDigestScan) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.buckets == nil {
+		d.buckets = make(map[string]MetadataDigest)
+	}
+	d.buckets[bucket] = MetadataDigest{
+		Digest:  scan.digest,
+		Objects: scan.objects,
+		Updated: UTCNow(),
+	}
+}
+
+// current returns the drive-wide digest: the xor of all per-bucket
+// digests, with Updated the time of the most recent bucket scan.
+func (d *driveMetaDigest) current() (digest MetadataDigest) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, b := range d.buckets {
+		digest.Digest ^= b.Digest
+		digest.Objects += b.Objects
+		if b.Updated.After(digest.Updated) {
+			digest.Updated = b.Updated
+		}
+	}
+	return digest
+}
+
+var globalDriveMetaDigests = newDriveRegistry(func() *driveMetaDigest {
+	return &driveMetaDigest{}
+})
+
+// driveMetaDigestFor returns the metadata digest holder for the given
+// endpoint, creating it on first use.
+func driveMetaDigestFor(endpoint string) *driveMetaDigest {
+	return globalDriveMetaDigests.get(endpoint)
+}
@@ -191,6 +191,26 @@ func collectLocalDisksMetrics(disks map[string]struct{}) map[string]madmin.DiskM
 			}
 		}
 
+		// Fold in per-method internode handler counters for this
+		// drive, under an "internode." prefix.
+		if ops := globalInternodeHandlerStats.lifeTimeOps(d.Endpoint); len(ops) > 0 {
+			if dm.LifeTimeOps == nil {
+				dm.LifeTimeOps = make(map[string]uint64, len(ops))
+			}
+			for k, v := range ops {
+				dm.LifeTimeOps[k] = v
+			}
+		}
+
+		if ops := driveWriteGateFor(d.Endpoint).lifeTimeOps(); len(ops) > 0 {
+			if dm.LifeTimeOps == nil {
+				dm.LifeTimeOps = make(map[string]uint64, len(ops))
+			}
+			for k, v := range ops {
+				dm.LifeTimeOps[k] = v
+			}
+		}
+
 		st, err := disk.GetDriveStats(d.Major, d.Minor)
 		if err == nil {
 			dm.IOStats = madmin.DiskIOStats{
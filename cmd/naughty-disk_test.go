@@ -201,6 +201,13 @@ func (d *naughtyDisk) AppendFile(ctx context.Context, volume string, path string
 	return d.disk.AppendFile(ctx, volume, path, buf)
 }
 
+func (d *naughtyDisk) AppendFileIfExists(ctx context.Context, volume string, path string, buf []byte) error {
+	if err := d.calcError(); err != nil {
+		return err
+	}
+	return d.disk.AppendFileIfExists(ctx, volume, path, buf)
+}
+
 func (d *naughtyDisk) RenameData(ctx context.Context, srcVolume, srcPath string, fi FileInfo, dstVolume, dstPath string, opts RenameOptions) (RenameDataResp, error) {
 	if err := d.calcError(); err != nil {
 		return RenameDataResp{}, err
@@ -1120,7 +1120,7 @@ func (s *peerRESTServer) SpeedTestHandler(w http.ResponseWriter, r *http.Request
 		duration = time.Second * 10
 	}
 
-	done := keepHTTPResponseAlive(w)
+	done := keepHTTPResponseAlive(w, "SpeedTest")
 
 	result, err := selfSpeedTest(r.Context(), speedTestOpts{
 		objectSize:      size,
@@ -1182,7 +1182,7 @@ func (s *peerRESTServer) DriveSpeedTestHandler(w http.ResponseWriter, r *http.Re
 		FileSize:  fileSize,
 	}
 
-	done := keepHTTPResponseAlive(w)
+	done := keepHTTPResponseAlive(w, "DriveSpeedTest")
 	result := driveSpeedTest(r.Context(), opts)
 	done(nil)
 
@@ -1349,7 +1349,7 @@ func (s *peerRESTServer) MakeBucketHandler(mss *grid.MSS) (np grid.NoPayload, ne
 // registerPeerRESTHandlers - register peer rest router.
 func registerPeerRESTHandlers(router *mux.Router, gm *grid.Manager) {
 	h := func(f http.HandlerFunc) http.HandlerFunc {
-		return collectInternodeStats(httpTraceHdrs(f))
+		return collectInternodeStats("", httpTraceHdrs(f))
 	}
 
 	server := &peerRESTServer{}
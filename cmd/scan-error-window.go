@@ -0,0 +1,77 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// scanErrorWindowMinutes is the width of the sliding window over
+// which per-drive scan error rates are computed.
+const scanErrorWindowMinutes = 10
+
+// scanErrorWindow keeps a per-minute ring buffer of scan errors for
+// one drive. Unlike a lifetime counter, the windowed rate decays as
+// time advances, so a rising rate signals an actively-failing drive.
+type scanErrorWindow struct {
+	mu      sync.Mutex
+	counts  [scanErrorWindowMinutes]uint64
+	minutes [scanErrorWindowMinutes]int64 // unix minute each slot covers
+}
+
+func (w *scanErrorWindow) addAt(now time.Time) {
+	minute := now.Unix() / 60
+	slot := int(minute % scanErrorWindowMinutes)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.minutes[slot] != minute {
+		// Slot last covered an older minute, reclaim it.
+		w.minutes[slot] = minute
+		w.counts[slot] = 0
+	}
+	w.counts[slot]++
+}
+
+func (w *scanErrorWindow) add() {
+	w.addAt(time.Now())
+}
+
+// rateAt returns the number of errors inside the window ending at now
+// and the average errors per minute over the full window width.
+func (w *scanErrorWindow) rateAt(now time.Time) (total uint64, perMinute float64) {
+	minute := now.Unix() / 60
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, m := range w.minutes {
+		if m > 0 && minute-m < scanErrorWindowMinutes {
+			total += w.counts[i]
+		}
+	}
+	return total, float64(total) / scanErrorWindowMinutes
+}
+
+var globalScanErrorWindows = newDriveRegistry(func() *scanErrorWindow {
+	return &scanErrorWindow{}
+})
+
+// scanErrorWindowFor returns the scan error window for the given
+// endpoint, creating it on first use.
+func scanErrorWindowFor(endpoint string) *scanErrorWindow {
+	return globalScanErrorWindows.get(endpoint)
+}
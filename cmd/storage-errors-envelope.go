@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Storage errors cross the wire as a small versioned envelope instead
+// of a bare err.Error() string, so the client maps them back to the
+// canonical sentinels by a stable numeric code rather than by matching
+// the message text, which breaks whenever a message is reworded.
+//
+// The code table is append-only: codes are never renumbered or reused,
+// a decoder seeing an unknown code degrades to a generic error that
+// carries the message text.
+const storageErrEnvelopeVersion = 1
+
+// storageErrCode assigns each canonical storage sentinel its stable
+// wire code. Code 0 is reserved for errors outside this table, which
+// travel as text only.
+var storageErrCode = map[uint32]error{
+	1:  errUnexpected,
+	2:  errCorruptedFormat,
+	3:  errCorruptedBackend,
+	4:  errUnformattedDisk,
+	5:  errInconsistentDisk,
+	6:  errUnsupportedDisk,
+	7:  errDiskFull,
+	8:  errDiskNotDir,
+	9:  errDiskNotFound,
+	10: errDiskOngoingReq,
+	11: errDriveBusy,
+	12: errDriveIsRoot,
+	13: errFaultyRemoteDisk,
+	14: errFaultyDisk,
+	15: errDiskAccessDenied,
+	16: errFileNotFound,
+	17: errFileVersionNotFound,
+	18: errFileVersionNotModified,
+	19: errTooManyOpenFiles,
+	20: errFileNameTooLong,
+	21: errVolumeExists,
+	22: errIsNotRegular,
+	23: errPathNotFound,
+	24: errVolumeNotFound,
+	25: errVolumeNotEmpty,
+	26: errVolumeAccessDenied,
+	27: errFileAccessDenied,
+	28: errFileCorrupt,
+	29: errCorruptedData,
+	30: errBitrotHashAlgoInvalid,
+	31: errCrossDeviceLink,
+	32: errLessData,
+	33: errShortShard,
+	34: errMoreData,
+	35: errMaxVersionsExceeded,
+	36: errDiskStale,
+	37: errInvalidArgument,
+	38: errInvalidAccessKeyID,
+	39: errAccessKeyDisabled,
+	40: errNoAuthToken,
+	41: errMalformedAuth,
+	42: errAuthentication,
+	43: errSkewedAuthTime,
+	44: errRPCAPIVersionUnsupported,
+	45: errServerTimeMismatch,
+}
+
+// storageCodeOfErr is the inverse of storageErrCode, built once at
+// startup.
+var storageCodeOfErr = func() map[error]uint32 {
+	m := make(map[error]uint32, len(storageErrCode))
+	for code, err := range storageErrCode {
+		m[err] = code
+	}
+	return m
+}()
+
+// storageErrEnvelope is the wire form of a storage error.
+type storageErrEnvelope struct {
+	Version int    `json:"v"`
+	Code    uint32 `json:"c"`
+	Message string `json:"m"`
+}
+
+// storageErrEncode renders err as its wire envelope. Errors outside
+// the code table travel as code 0 with their text.
+func storageErrEncode(err error) string {
+	env := storageErrEnvelope{
+		Version: storageErrEnvelopeVersion,
+		Code:    storageCodeOfErr[err],
+		Message: err.Error(),
+	}
+	buf, jerr := json.Marshal(env)
+	if jerr != nil {
+		// Cannot happen for a struct of ints and a string, but an
+		// error string is still better than an empty body.
+		return err.Error()
+	}
+	return string(buf)
+}
+
+// storageErrDecode parses an error envelope back into the canonical
+// sentinel for its code. Code 0 and codes this build does not know
+// yield a generic error carrying the message text. Returns false when
+// s is not an envelope at all, e.g. a response from an older peer.
+func storageErrDecode(s string) (error, bool) {
+	if !strings.HasPrefix(s, "{") {
+		return nil, false
+	}
+	var env storageErrEnvelope
+	if err := json.Unmarshal([]byte(s), &env); err != nil || env.Version < 1 {
+		return nil, false
+	}
+	if err, ok := storageErrCode[env.Code]; ok {
+		return err, true
+	}
+	if env.Message != "" {
+		return StorageErr(env.Message), true
+	}
+	return StorageErr(fmt.Sprintf("unknown storage error (code %d)", env.Code)), true
+}
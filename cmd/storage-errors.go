@@ -55,6 +55,10 @@ var errDiskNotFound = StorageErr("drive not found")
 // errDiskOngoingReq - indicates if the disk has an on-going request in progress.
 var errDiskOngoingReq = StorageErr("drive still did not complete the request")
 
+// errDriveBusy - the drive is serving its maximum number of concurrent
+// heavy operations, the caller should back off and retry.
+var errDriveBusy = StorageErr("drive is busy with too many heavy operations, please retry")
+
 // errDriveIsRoot - cannot use the disk since its a root disk.
 var errDriveIsRoot = StorageErr("drive is part of root drive, will not be used")
 
@@ -73,6 +77,12 @@ var errFileNotFound = StorageErr("file not found")
 // errFileNotFound - cannot find requested file version.
 var errFileVersionNotFound = StorageErr("file version not found")
 
+// errFileVersionNotModified - returned by conditional reads when the
+// on-disk version still matches the caller-supplied signature, so the
+// full metadata payload is skipped. Distinct from errFileVersionNotFound,
+// the version exists and simply has not changed.
+var errFileVersionNotModified = StorageErr("file version not modified")
+
 // errTooManyOpenFiles - too many open files.
 var errTooManyOpenFiles = StorageErr("too many open files, please increase 'ulimit -n'")
 
@@ -103,6 +113,10 @@ var errFileAccessDenied = StorageErr("file access denied")
 // errFileCorrupt - file has an unexpected size, or is not readable
 var errFileCorrupt = StorageErr("file is corrupted")
 
+// errCorruptedData - a block failed its bitrot checksum during a
+// verified read.
+var errCorruptedData = StorageErr("data is corrupted")
+
 // errBitrotHashAlgoInvalid - the algo for bit-rot hash
 // verification is empty or invalid.
 var errBitrotHashAlgoInvalid = StorageErr("bit-rot hash algorithm is invalid")
@@ -113,6 +127,10 @@ var errCrossDeviceLink = StorageErr("Rename across devices not allowed, please f
 // errLessData - returned when less data available than what was requested.
 var errLessData = StorageErr("less data available than what was requested")
 
+// errShortShard - a shard file holds fewer bytes than its metadata
+// says it should, the coordinator should reconstruct from parity.
+var errShortShard = StorageErr("shard has less data than expected")
+
 // errMoreData = returned when more data was sent by the caller than what it was supposed to.
 var errMoreData = StorageErr("more data was sent than what was advertised")
 
@@ -20,10 +20,12 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/gob"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"net/url"
@@ -34,6 +36,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/klauspost/compress/s2"
 	"github.com/minio/madmin-go/v3"
 	"github.com/minio/minio/internal/cachevalue"
 	"github.com/minio/minio/internal/grid"
@@ -89,6 +92,13 @@ func toStorageErr(err error) error {
 		return errDiskNotFound
 	}
 
+	// Errors from current peers arrive as a coded envelope; decode it
+	// first, the string matching below then only canonicalizes (e.g.
+	// errDiskStale) and covers grid errors and text-only responses.
+	if decoded, ok := storageErrDecode(err.Error()); ok {
+		err = decoded
+	}
+
 	switch err.Error() {
 	case errFaultyDisk.Error():
 		return errFaultyDisk
@@ -100,6 +110,8 @@ func toStorageErr(err error) error {
 		return errUnexpected
 	case errDiskFull.Error():
 		return errDiskFull
+	case errDriveBusy.Error():
+		return errDriveBusy
 	case errVolumeNotFound.Error():
 		return errVolumeNotFound
 	case errVolumeExists.Error():
@@ -108,6 +120,8 @@ func toStorageErr(err error) error {
 		return errFileNotFound
 	case errFileVersionNotFound.Error():
 		return errFileVersionNotFound
+	case errFileVersionNotModified.Error():
+		return errFileVersionNotModified
 	case errFileNameTooLong.Error():
 		return errFileNameTooLong
 	case errFileAccessDenied.Error():
@@ -122,6 +136,12 @@ func toStorageErr(err error) error {
 		return errVolumeAccessDenied
 	case errCorruptedFormat.Error():
 		return errCorruptedFormat
+	case errCorruptedData.Error():
+		return errCorruptedData
+	case errInvalidArgument.Error():
+		return errInvalidArgument
+	case errShortShard.Error():
+		return errShortShard
 	case errCorruptedBackend.Error():
 		return errCorruptedBackend
 	case errUnformattedDisk.Error():
@@ -138,6 +158,10 @@ func toStorageErr(err error) error {
 		return io.EOF
 	case io.ErrUnexpectedEOF.Error():
 		return io.ErrUnexpectedEOF
+	case context.Canceled.Error():
+		return context.Canceled
+	case context.DeadlineExceeded.Error():
+		return context.DeadlineExceeded
 	case errDiskStale.Error():
 		return errDiskNotFound
 	case errDiskNotFound.Error():
@@ -294,6 +318,23 @@ func (client *storageRESTClient) SetDiskID(id string) {
 	client.diskID.Store(&id)
 }
 
+// DiskSnapshot - fetches the remote drive's DiskInfo and the VolInfo
+// of the given volumes in a single round-trip, so monitoring sees one
+// coherent view instead of stitching separate calls together. Volume
+// results are positional, a non-empty VolErrs entry carries that
+// volume's failure without failing the snapshot.
+func (client *storageRESTClient) DiskSnapshot(ctx context.Context, metrics bool, volumes ...string) (snap DiskSnapshot, err error) {
+	resp, err := storageDiskSnapshotRPC.Call(ctx, client.gridConn, &DiskSnapshotReq{
+		DiskID:  *client.diskID.Load(),
+		Metrics: metrics,
+		Volumes: volumes,
+	})
+	if err != nil {
+		return snap, toStorageErr(err)
+	}
+	return *resp, nil
+}
+
 func (client *storageRESTClient) DiskInfo(ctx context.Context, opts DiskInfoOptions) (info DiskInfo, err error) {
 	if !client.IsOnlineWS() {
 		// make sure to check if the disk is offline, since the underlying
@@ -358,12 +399,22 @@ func (client *storageRESTClient) ListVols(ctx context.Context) (vols []VolInfo,
 	return nil, errInvalidArgument
 }
 
+// withGridDeadline stamps the caller's deadline, if any, onto grid
+// params so the remote handler can stop a disk operation once the
+// caller has given up waiting.
+func withGridDeadline(ctx context.Context, values *grid.MSS) *grid.MSS {
+	if deadline, ok := ctx.Deadline(); ok {
+		values.Set(storageRESTDeadline, strconv.FormatInt(deadline.UnixNano(), 10))
+	}
+	return values
+}
+
 // StatVol - get volume info over the network.
 func (client *storageRESTClient) StatVol(ctx context.Context, volume string) (vol VolInfo, err error) {
-	v, err := storageStatVolRPC.Call(ctx, client.gridConn, grid.NewMSSWith(map[string]string{
+	v, err := storageStatVolRPC.Call(ctx, client.gridConn, withGridDeadline(ctx, grid.NewMSSWith(map[string]string{
 		storageRESTDiskID: *client.diskID.Load(),
 		storageRESTVolume: volume,
-	}))
+	})))
 	if err != nil {
 		return vol, toStorageErr(err)
 	}
@@ -378,17 +429,28 @@ func (client *storageRESTClient) DeleteVol(ctx context.Context, volume string, f
 	return errInvalidArgument
 }
 
-// AppendFile - append to a file.
-func (client *storageRESTClient) AppendFile(ctx context.Context, volume string, path string, buf []byte) error {
+func (client *storageRESTClient) appendFile(ctx context.Context, volume, path string, buf []byte, create bool) error {
 	values := make(url.Values)
 	values.Set(storageRESTVolume, volume)
 	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTCreate, strconv.FormatBool(create))
 	reader := bytes.NewReader(buf)
 	respBody, err := client.call(ctx, storageRESTMethodAppendFile, values, reader, -1)
 	defer xhttp.DrainBody(respBody)
 	return err
 }
 
+// AppendFile - append to a file, creating it if absent.
+func (client *storageRESTClient) AppendFile(ctx context.Context, volume string, path string, buf []byte) error {
+	return client.appendFile(ctx, volume, path, buf, true)
+}
+
+// AppendFileIfExists - append to an existing file, a missing path is
+// rejected with errFileNotFound instead of being created.
+func (client *storageRESTClient) AppendFileIfExists(ctx context.Context, volume string, path string, buf []byte) error {
+	return client.appendFile(ctx, volume, path, buf, false)
+}
+
 func (client *storageRESTClient) CreateFile(ctx context.Context, origvolume, volume, path string, size int64, reader io.Reader) error {
 	values := make(url.Values)
 	values.Set(storageRESTVolume, volume)
@@ -477,6 +539,21 @@ func (client *storageRESTClient) CheckParts(ctx context.Context, volume string,
 	return resp, nil
 }
 
+// StatParts stats many parts of one object in a single call. Results
+// come back in input order, each with its own error slot.
+func (client *storageRESTClient) StatParts(ctx context.Context, volume, path string, parts []int) (*StatPartsResp, error) {
+	resp, err := storageStatPartsRPC.Call(ctx, client.gridConn, &StatPartsReq{
+		DiskID: *client.diskID.Load(),
+		Volume: volume,
+		Path:   path,
+		Parts:  parts,
+	})
+	if err != nil {
+		return nil, toStorageErr(err)
+	}
+	return resp, nil
+}
+
 // RenameData - rename source path to destination path atomically, metadata and data file.
 func (client *storageRESTClient) RenameData(ctx context.Context, srcVolume, srcPath string, fi FileInfo,
 	dstVolume, dstPath string, opts RenameOptions,
@@ -533,7 +610,59 @@ func (client *storageRESTClient) ReadVersion(ctx context.Context, origvolume, vo
 
 	// Use websocket when not reading data.
 	if !opts.ReadData {
-		resp, err := storageReadVersionRPC.Call(ctx, client.gridConn, grid.NewMSSWith(map[string]string{
+		resp, err := storageReadVersionRPC.Call(ctx, client.gridConn, withGridDeadline(ctx, grid.NewMSSWith(map[string]string{
+			storageRESTDiskID:           *client.diskID.Load(),
+			storageRESTOrigVolume:       origvolume,
+			storageRESTVolume:           volume,
+			storageRESTFilePath:         path,
+			storageRESTVersionID:        versionID,
+			storageRESTInclFreeVersions: strconv.FormatBool(opts.InclFreeVersions),
+			storageRESTHealing:          strconv.FormatBool(opts.Healing),
+		})))
+		if err != nil {
+			return fi, toStorageErr(err)
+		}
+		return *resp, nil
+	}
+
+	values := make(url.Values)
+	values.Set(storageRESTOrigVolume, origvolume)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTVersionID, versionID)
+	values.Set(storageRESTInclFreeVersions, strconv.FormatBool(opts.InclFreeVersions))
+	values.Set(storageRESTHealing, strconv.FormatBool(opts.Healing))
+
+	respBody, err := client.callGet(ctx, storageRESTMethodReadVersion, values, nil, -1)
+	if err != nil {
+		return fi, err
+	}
+	defer xhttp.DrainBody(respBody)
+
+	dec := msgpNewReader(respBody)
+	defer readMsgpReaderPoolPut(dec)
+
+	err = fi.DecodeMsg(dec)
+	return fi, err
+}
+
+// ReadVersionCached - like ReadVersion, but sends the cached
+// FileInfo's modtime as a signature so the remote node skips
+// re-serializing the metadata when the on-disk version has not
+// changed, in which case the cached copy is returned as is. With a nil
+// cache it behaves exactly like ReadVersion.
+func (client *storageRESTClient) ReadVersionCached(ctx context.Context, origvolume, volume, path, versionID string, opts ReadOptions, cached *FileInfo) (fi FileInfo, err error) {
+	if cached == nil {
+		return client.ReadVersion(ctx, origvolume, volume, path, versionID, opts)
+	}
+	sig := strconv.FormatInt(cached.ModTime.UnixNano(), 10)
+
+	ctx, cancel := context.WithTimeout(ctx, globalDriveConfig.GetMaxTimeout())
+	defer cancel()
+
+	// Use websocket when not reading data.
+	if !opts.ReadData {
+		resp, err := storageReadVersionRPC.Call(ctx, client.gridConn, withGridDeadline(ctx, grid.NewMSSWith(map[string]string{
 			storageRESTDiskID:           *client.diskID.Load(),
 			storageRESTOrigVolume:       origvolume,
 			storageRESTVolume:           volume,
@@ -541,8 +670,12 @@ func (client *storageRESTClient) ReadVersion(ctx context.Context, origvolume, vo
 			storageRESTVersionID:        versionID,
 			storageRESTInclFreeVersions: strconv.FormatBool(opts.InclFreeVersions),
 			storageRESTHealing:          strconv.FormatBool(opts.Healing),
-		}))
+			storageRESTIfModSig:         sig,
+		})))
 		if err != nil {
+			if errors.Is(toStorageErr(err), errFileVersionNotModified) {
+				return *cached, nil
+			}
 			return fi, toStorageErr(err)
 		}
 		return *resp, nil
@@ -555,9 +688,13 @@ func (client *storageRESTClient) ReadVersion(ctx context.Context, origvolume, vo
 	values.Set(storageRESTVersionID, versionID)
 	values.Set(storageRESTInclFreeVersions, strconv.FormatBool(opts.InclFreeVersions))
 	values.Set(storageRESTHealing, strconv.FormatBool(opts.Healing))
+	values.Set(storageRESTIfModSig, sig)
 
 	respBody, err := client.callGet(ctx, storageRESTMethodReadVersion, values, nil, -1)
 	if err != nil {
+		if errors.Is(err, errFileVersionNotModified) {
+			return *cached, nil
+		}
 		return fi, err
 	}
 	defer xhttp.DrainBody(respBody)
@@ -569,6 +706,68 @@ func (client *storageRESTClient) ReadVersion(ctx context.Context, origvolume, vo
 	return fi, err
 }
 
+// ReadVersions reads metadata for a batch of versions in a single
+// round-trip, at most readVersionsMaxBatch entries at a time. Results
+// are positional: fis[i] and errs[i] correspond to entries[i], and one
+// failed entry does not abort the rest of the batch.
+func (client *storageRESTClient) ReadVersions(ctx context.Context, entries []ReadVersionsEntry) (fis []*FileInfo, errs []error) {
+	fis = make([]*FileInfo, len(entries))
+	errs = make([]error, len(entries))
+	if len(entries) == 0 {
+		return fis, errs
+	}
+
+	st, err := storageReadVersionsRPC.Call(ctx, client.gridConn, &ReadVersionsReq{
+		DiskID:  *client.diskID.Load(),
+		Entries: entries,
+	})
+	if err != nil {
+		for i := range errs {
+			errs[i] = toStorageErr(err)
+		}
+		return fis, errs
+	}
+	err = st.Results(func(resp *ReadVersionsResp) error {
+		if resp.Idx < 0 || resp.Idx >= len(entries) {
+			return errInvalidArgument
+		}
+		if resp.Err != "" {
+			errs[resp.Idx] = toStorageErr(errors.New(resp.Err))
+			return nil
+		}
+		// The response is pooled once this callback returns, keep a copy.
+		fi := *resp.FI
+		fis[resp.Idx] = &fi
+		return nil
+	})
+	if err != nil {
+		err = toStorageErr(err)
+		for i := range errs {
+			if fis[i] == nil && errs[i] == nil {
+				errs[i] = err
+			}
+		}
+	}
+	return fis, errs
+}
+
+// ReadAllEncoded reads a file's full content with an optional
+// server-side encoding ("base64", "hex" or "" for raw bytes), the
+// returned bytes are in the requested encoding.
+func (client *storageRESTClient) ReadAllEncoded(ctx context.Context, volume, path, encoding string) ([]byte, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTEncoding, encoding)
+	respBody, err := client.callGet(ctx, storageRESTMethodReadAll, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer xhttp.DrainBody(respBody)
+	buf, err := io.ReadAll(respBody)
+	return buf, toStorageErr(err)
+}
+
 // ReadXL - reads all contents of xl.meta of a file.
 func (client *storageRESTClient) ReadXL(ctx context.Context, volume string, path string, readData bool) (rf RawFileInfo, err error) {
 	ctx, cancel := context.WithTimeout(ctx, globalDriveConfig.GetMaxTimeout())
@@ -576,11 +775,11 @@ func (client *storageRESTClient) ReadXL(ctx context.Context, volume string, path
 
 	// Use websocket when not reading data.
 	if !readData {
-		resp, err := storageReadXLRPC.Call(ctx, client.gridConn, grid.NewMSSWith(map[string]string{
+		resp, err := storageReadXLRPC.Call(ctx, client.gridConn, withGridDeadline(ctx, grid.NewMSSWith(map[string]string{
 			storageRESTDiskID:   *client.diskID.Load(),
 			storageRESTVolume:   volume,
 			storageRESTFilePath: path,
-		}))
+		})))
 		if err != nil {
 			return rf, toStorageErr(err)
 		}
@@ -621,6 +820,64 @@ func (client *storageRESTClient) ReadAll(ctx context.Context, volume string, pat
 	return *gridBytes, nil
 }
 
+// trailingStatusReader strips the single status byte that
+// ReadFileStreamHandler appends after the body and turns a failure
+// marker into io.ErrUnexpectedEOF, so a stream truncated by a mid-read
+// error on the server is not mistaken for a clean end of stream.
+type trailingStatusReader struct {
+	rc   io.ReadCloser
+	hold [1]byte
+	have bool
+	err  error
+}
+
+func (r *trailingStatusReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if !r.have {
+		if _, err := io.ReadFull(r.rc, r.hold[:]); err != nil {
+			// Not even the status marker arrived.
+			r.err = io.ErrUnexpectedEOF
+			return 0, r.err
+		}
+		r.have = true
+	}
+	n, err := r.rc.Read(p)
+	if n > 0 {
+		// Deliver the held byte first and hold back the last byte
+		// read, it may be the status marker.
+		last := p[n-1]
+		copy(p[1:n], p[:n-1])
+		p[0] = r.hold[0]
+		r.hold[0] = last
+	}
+	switch {
+	case err == nil:
+		return n, nil
+	case errors.Is(err, io.EOF):
+		if r.hold[0] == 0 {
+			r.err = io.EOF
+		} else {
+			r.err = io.ErrUnexpectedEOF
+		}
+		if n > 0 {
+			return n, nil
+		}
+		return 0, r.err
+	default:
+		r.err = err
+		return n, err
+	}
+}
+
+func (r *trailingStatusReader) Close() error {
+	return r.rc.Close()
+}
+
 // ReadFileStream - returns a reader for the requested file.
 func (client *storageRESTClient) ReadFileStream(ctx context.Context, volume, path string, offset, length int64) (io.ReadCloser, error) {
 	values := make(url.Values)
@@ -633,7 +890,65 @@ func (client *storageRESTClient) ReadFileStream(ctx context.Context, volume, pat
 	if err != nil {
 		return nil, toStorageErr(err)
 	}
-	return respBody, nil
+	if length < 0 {
+		// Unknown length, the server does not append a status marker.
+		return respBody, nil
+	}
+	return &trailingStatusReader{rc: respBody}, nil
+}
+
+// hashVerifyReader hashes everything read and, at EOF, compares the
+// digest against the hex sum the server left in the response trailer.
+// A missing or undecodable trailer digest fails the read as well, the
+// caller asked for verification and cannot get it.
+type hashVerifyReader struct {
+	rc      io.ReadCloser
+	h       hash.Hash
+	trailer http.Header
+}
+
+func (r *hashVerifyReader) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	if n > 0 {
+		r.h.Write(p[:n])
+	}
+	if errors.Is(err, io.EOF) {
+		sum, decErr := hex.DecodeString(r.trailer.Get(xhttp.MinIOReadHashSum))
+		if decErr != nil || len(sum) == 0 || !bytes.Equal(sum, r.h.Sum(nil)) {
+			return n, errCorruptedData
+		}
+	}
+	return n, err
+}
+
+func (r *hashVerifyReader) Close() error {
+	return r.rc.Close()
+}
+
+// ReadFileStreamVerified - like ReadFileStream, but asks the server to
+// hash the section with the given bitrot algorithm while streaming and
+// verifies the bytes received against the digest in the response
+// trailer once the stream is drained. Older peers that do not emit the
+// trailer fail the read with errCorruptedData.
+func (client *storageRESTClient) ReadFileStreamVerified(ctx context.Context, volume, path string, offset, length int64, algo BitrotAlgorithm) (io.ReadCloser, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTOffset, strconv.Itoa(int(offset)))
+	values.Set(storageRESTLength, strconv.Itoa(int(length)))
+	values.Set(storageRESTDiskID, *client.diskID.Load())
+
+	header := make(http.Header)
+	header.Set(xhttp.MinIOReadHashAlgo, algo.String())
+	respBody, trailer, err := client.restClient.CallWithTrailer(ctx, http.MethodGet, storageRESTMethodReadFileStream, values, nil, -1, header)
+	if err != nil {
+		return nil, toStorageErr(err)
+	}
+	var rc io.ReadCloser = respBody
+	if length >= 0 {
+		rc = &trailingStatusReader{rc: respBody}
+	}
+	return &hashVerifyReader{rc: rc, h: algo.New(), trailer: trailer}, nil
 }
 
 // ReadFile - reads section of a file.
@@ -659,6 +974,72 @@ func (client *storageRESTClient) ReadFile(ctx context.Context, volume string, pa
 	return int64(n), toStorageErr(err)
 }
 
+// ReadFileVerified - reads a section of a streaming-bitrot formatted
+// file, the server verifies each block's checksum while reading and
+// fails with errCorruptedData at the first bad block, so serving and
+// verification happen in a single pass.
+func (client *storageRESTClient) ReadFileVerified(ctx context.Context, volume, path string, offset int64, buf []byte, algo BitrotAlgorithm, shardSize int64) (int64, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTOffset, strconv.Itoa(int(offset)))
+	values.Set(storageRESTLength, strconv.Itoa(len(buf)))
+	values.Set(storageRESTBitrotAlgo, algo.String())
+	values.Set(storageRESTShardSize, strconv.FormatInt(shardSize, 10))
+	respBody, err := client.callGet(ctx, storageRESTMethodReadFile, values, nil, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer xhttp.DrainBody(respBody)
+	n, err := io.ReadFull(respBody, buf)
+	return int64(n), toStorageErr(err)
+}
+
+// OpenReadSession declares an overall range the caller intends to read
+// sequentially, the server prefetches it so subsequent ReadSession calls
+// are served from memory. Returns the session id.
+func (client *storageRESTClient) OpenReadSession(ctx context.Context, volume, path string, offset, length int64) (string, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTOffset, strconv.FormatInt(offset, 10))
+	values.Set(storageRESTLength, strconv.FormatInt(length, 10))
+	respBody, err := client.call(ctx, storageRESTMethodOpenReadSess, values, nil, -1)
+	if err != nil {
+		return "", err
+	}
+	defer xhttp.DrainBody(respBody)
+	id, err := io.ReadAll(respBody)
+	if err != nil {
+		return "", toStorageErr(err)
+	}
+	return string(id), nil
+}
+
+// ReadSession reads a section of the file backing an open read session.
+func (client *storageRESTClient) ReadSession(ctx context.Context, sessionID string, offset int64, buf []byte) (int64, error) {
+	values := make(url.Values)
+	values.Set(storageRESTSessionID, sessionID)
+	values.Set(storageRESTOffset, strconv.FormatInt(offset, 10))
+	values.Set(storageRESTLength, strconv.Itoa(len(buf)))
+	respBody, err := client.callGet(ctx, storageRESTMethodReadSess, values, nil, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer xhttp.DrainBody(respBody)
+	n, err := io.ReadFull(respBody, buf)
+	return int64(n), toStorageErr(err)
+}
+
+// CloseReadSession releases a read session and its prefetch buffer.
+func (client *storageRESTClient) CloseReadSession(ctx context.Context, sessionID string) error {
+	values := make(url.Values)
+	values.Set(storageRESTSessionID, sessionID)
+	respBody, err := client.call(ctx, storageRESTMethodCloseReadSess, values, nil, -1)
+	defer xhttp.DrainBody(respBody)
+	return err
+}
+
 // ListDir - lists a directory.
 func (client *storageRESTClient) ListDir(ctx context.Context, origvolume, volume, dirPath string, count int) (entries []string, err error) {
 	values := grid.NewMSS()
@@ -668,17 +1049,47 @@ func (client *storageRESTClient) ListDir(ctx context.Context, origvolume, volume
 	values.Set(storageRESTOrigVolume, origvolume)
 	values.Set(storageRESTDiskID, *client.diskID.Load())
 
-	st, err := storageListDirRPC.Call(ctx, client.gridConn, values)
+	st, err := storageListDirChunkedRPC.Call(ctx, client.gridConn, values)
 	if err != nil {
 		return nil, toStorageErr(err)
 	}
 	err = st.Results(func(resp *ListDirResult) error {
-		entries = resp.Entries
+		entries = append(entries, resp.Entries...)
 		return nil
 	})
 	return entries, toStorageErr(err)
 }
 
+// ListDirBudget lists a directory like ListDir but gives the remote
+// node a time budget. When the budget expires before the listing
+// completes, the entries gathered so far are returned with truncated
+// set and a token that resumes the listing after the last entry seen.
+func (client *storageRESTClient) ListDirBudget(ctx context.Context, origvolume, volume, dirPath string, count int, budget time.Duration, token string) (entries []string, truncated bool, next string, err error) {
+	values := grid.NewMSS()
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTDirPath, dirPath)
+	values.Set(storageRESTCount, strconv.Itoa(count))
+	values.Set(storageRESTOrigVolume, origvolume)
+	values.Set(storageRESTBudget, budget.String())
+	values.Set(storageRESTToken, token)
+	values.Set(storageRESTDiskID, *client.diskID.Load())
+
+	st, err := storageListDirBudgetRPC.Call(ctx, client.gridConn, values)
+	if err != nil {
+		return nil, false, "", toStorageErr(err)
+	}
+	err = st.Results(func(resp *ListDirBudgetResult) error {
+		if resp.Truncated {
+			truncated = true
+			next = resp.Token
+			return nil
+		}
+		entries = append(entries, resp.Entries...)
+		return nil
+	})
+	return entries, truncated, next, toStorageErr(err)
+}
+
 // DeleteFile - deletes a file.
 func (client *storageRESTClient) Delete(ctx context.Context, volume string, path string, deleteOpts DeleteOptions) error {
 	if !deleteOpts.Immediate {
@@ -728,31 +1139,37 @@ func (client *storageRESTClient) DeleteVersions(ctx context.Context, volume stri
 		return errs
 	}
 
-	reader, err := waitForHTTPResponse(respBody)
-	if err != nil {
-		for i := range errs {
-			errs[i] = toStorageErr(err)
+	// Results arrive incrementally, one record per entry as the
+	// server completes it; reconstruct the ordered error slice.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(waitForHTTPStream(respBody, ioutil.NewDeadlineWriter(pw, globalDriveConfig.GetMaxTimeout())))
+	}()
+	mr := msgp.NewReader(pr)
+	reported := make([]bool, len(errs))
+	for seen := 0; seen < len(versions); seen++ {
+		var res DeleteVersionsStreamResult
+		if derr := res.DecodeMsg(mr); derr != nil {
+			if errors.Is(derr, io.EOF) {
+				derr = io.ErrUnexpectedEOF
+			}
+			pr.CloseWithError(derr)
+			for i := range errs {
+				if !reported[i] {
+					errs[i] = toStorageErr(derr)
+				}
+			}
+			return errs
 		}
-		return errs
-	}
-
-	dErrResp := &DeleteVersionsErrsResp{}
-	decoder := msgpNewReader(reader)
-	defer readMsgpReaderPoolPut(decoder)
-	if err = dErrResp.DecodeMsg(decoder); err != nil {
-		for i := range errs {
-			errs[i] = toStorageErr(err)
+		if res.Idx < 0 || res.Idx >= len(errs) {
+			continue
 		}
-		return errs
-	}
-
-	for i, dErr := range dErrResp.Errs {
-		if dErr != "" {
-			errs[i] = toStorageErr(errors.New(dErr))
-		} else {
-			errs[i] = nil
+		reported[res.Idx] = true
+		if res.Err != "" {
+			errs[res.Idx] = toStorageErr(errors.New(res.Err))
 		}
 	}
+	pr.CloseWithError(nil)
 
 	return errs
 }
@@ -850,6 +1267,51 @@ func (client *storageRESTClient) VerifyFile(ctx context.Context, volume, path st
 	return verifyResp, nil
 }
 
+// VerifyFileProgress is like VerifyFile but receives per-part progress
+// callbacks while the remote bitrot scan runs, so callers can show how
+// far a scan over a large object has come.
+func (client *storageRESTClient) VerifyFileProgress(ctx context.Context, volume, path string, fi FileInfo, progress func(part int, bytesDone int64)) (*CheckPartsResp, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTProgress, "true")
+
+	var reader bytes.Buffer
+	if err := msgp.Encode(&reader, &fi); err != nil {
+		return nil, err
+	}
+
+	respBody, err := client.call(ctx, storageRESTMethodVerifyFile, values, &reader, -1)
+	defer xhttp.DrainBody(respBody)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(waitForHTTPStream(respBody, ioutil.NewDeadlineWriter(pw, globalDriveConfig.GetMaxTimeout())))
+	}()
+	mr := msgp.NewReader(pr)
+	for {
+		var rec VerifyFileProgress
+		if err := rec.DecodeMsg(mr); err != nil {
+			if errors.Is(err, io.EOF) {
+				// Stream ended without a terminal record.
+				err = io.ErrUnexpectedEOF
+			}
+			pr.CloseWithError(err)
+			return nil, toStorageErr(err)
+		}
+		if rec.Done {
+			pr.CloseWithError(nil)
+			return rec.Resp, nil
+		}
+		if progress != nil {
+			progress(rec.Part, rec.BytesDone)
+		}
+	}
+}
+
 func (client *storageRESTClient) DeleteBulk(ctx context.Context, volume string, paths ...string) (err error) {
 	values := make(url.Values)
 	values.Set(storageRESTVolume, volume)
@@ -870,6 +1332,34 @@ func (client *storageRESTClient) DeleteBulk(ctx context.Context, volume string,
 	return toStorageErr(err)
 }
 
+// MutateMetadata applies a list of metadata mutations to one version
+// on the remote drive all-or-nothing and returns the resulting
+// metadata.
+func (client *storageRESTClient) MutateMetadata(ctx context.Context, volume, path, versionID string, ops []MetaMutationOp) (map[string]string, error) {
+	req := &MetaMutationReq{
+		Volume:    volume,
+		FilePath:  path,
+		VersionID: versionID,
+		Ops:       ops,
+	}
+	body, err := req.MarshalMsg(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := client.call(ctx, storageRESTMethodMutateMeta, nil, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, toStorageErr(err)
+	}
+	defer xhttp.DrainBody(respBody)
+
+	var resp MetaMutationResp
+	if err = msgp.Decode(respBody, &resp); err != nil {
+		return nil, toStorageErr(err)
+	}
+	return resp.Metadata, nil
+}
+
 func (client *storageRESTClient) StatInfoFile(ctx context.Context, volume, path string, glob bool) (stat []StatInfo, err error) {
 	values := make(url.Values)
 	values.Set(storageRESTVolume, volume)
@@ -942,11 +1432,644 @@ func (client *storageRESTClient) ReadMultiple(ctx context.Context, req ReadMulti
 	}
 }
 
-// CleanAbandonedData will read metadata of the object on disk
-// and delete any data directories and inline data that isn't referenced in metadata.
-func (client *storageRESTClient) CleanAbandonedData(ctx context.Context, volume string, path string) error {
+// SetFsyncPolicy configures the drive's fsync batching interval, a zero
+// interval restores the default sync-per-write behavior.
+func (client *storageRESTClient) SetFsyncPolicy(ctx context.Context, interval time.Duration) error {
 	values := make(url.Values)
-	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTInterval, interval.String())
+	respBody, err := client.call(ctx, storageRESTMethodSetFsyncPolicy, values, nil, -1)
+	defer xhttp.DrainBody(respBody)
+	return err
+}
+
+// GetFsyncPolicy returns the drive's current fsync batching interval.
+func (client *storageRESTClient) GetFsyncPolicy(ctx context.Context) (time.Duration, error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodGetFsyncPolicy, nil, nil, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer xhttp.DrainBody(respBody)
+	buf, err := io.ReadAll(respBody)
+	if err != nil {
+		return 0, toStorageErr(err)
+	}
+	return time.ParseDuration(string(buf))
+}
+
+// SetScanBudget caps how many objects the remote drive's scanner
+// visits per cycle, zero removes the cap.
+func (client *storageRESTClient) SetScanBudget(ctx context.Context, limit int64) error {
+	values := make(url.Values)
+	values.Set(storageRESTCount, strconv.FormatInt(limit, 10))
+	respBody, err := client.call(ctx, storageRESTMethodSetScanBudget, values, nil, -1)
+	defer xhttp.DrainBody(respBody)
+	return err
+}
+
+// GetScanBudget returns the remote drive's current per-cycle scan
+// budget, zero means unlimited.
+func (client *storageRESTClient) GetScanBudget(ctx context.Context) (int64, error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodGetScanBudget, nil, nil, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer xhttp.DrainBody(respBody)
+	buf, err := io.ReadAll(respBody)
+	if err != nil {
+		return 0, toStorageErr(err)
+	}
+	return strconv.ParseInt(string(buf), 10, 64)
+}
+
+// SetTraceRate configures what percentage of the remote drive's
+// storage handler requests are traced, 100 traces every request.
+func (client *storageRESTClient) SetTraceRate(ctx context.Context, rate int64) error {
+	values := make(url.Values)
+	values.Set(storageRESTCount, strconv.FormatInt(rate, 10))
+	respBody, err := client.call(ctx, storageRESTMethodSetTraceRate, values, nil, -1)
+	defer xhttp.DrainBody(respBody)
+	return toStorageErr(err)
+}
+
+// GetTraceRate returns the remote drive's current trace sampling rate
+// in percent.
+func (client *storageRESTClient) GetTraceRate(ctx context.Context) (int64, error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodGetTraceRate, nil, nil, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer xhttp.DrainBody(respBody)
+	buf, err := io.ReadAll(respBody)
+	if err != nil {
+		return 0, toStorageErr(err)
+	}
+	return strconv.ParseInt(string(buf), 10, 64)
+}
+
+// ReadWeakETag returns a weak ETag for a version computed from metadata only.
+func (client *storageRESTClient) ReadWeakETag(ctx context.Context, volume, path, versionID string) (string, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTVersionID, versionID)
+	respBody, err := client.callGet(ctx, storageRESTMethodReadWeakETag, values, nil, -1)
+	if err != nil {
+		return "", err
+	}
+	defer xhttp.DrainBody(respBody)
+	etag, err := io.ReadAll(respBody)
+	if err != nil {
+		return "", toStorageErr(err)
+	}
+	return string(etag), nil
+}
+
+// ListOrphanedData returns data directories under volume, optionally limited
+// to path, that are not referenced by any version in their object's metadata.
+func (client *storageRESTClient) ListOrphanedData(ctx context.Context, volume, path string) (orphans []OrphanedDataInfo, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTDirPath, path)
+	respBody, err := client.call(ctx, storageRESTMethodListOrphans, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer xhttp.DrainBody(respBody)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(waitForHTTPStream(respBody, ioutil.NewDeadlineWriter(pw, globalDriveConfig.GetMaxTimeout())))
+	}()
+	mr := msgp.NewReader(pr)
+	for {
+		var info OrphanedDataInfo
+		if err := info.DecodeMsg(mr); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			pr.CloseWithError(err)
+			return orphans, toStorageErr(err)
+		}
+		orphans = append(orphans, info)
+	}
+}
+
+// ObjectTimestamps returns the object's derived creation time (the
+// earliest version's modtime) and latest modification time.
+func (client *storageRESTClient) ObjectTimestamps(ctx context.Context, volume, path string) (ts ObjectTimestamps, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	respBody, err := client.callGet(ctx, storageRESTMethodObjectTimes, values, nil, -1)
+	if err != nil {
+		return ts, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return ts, toStorageErr(msgp.Decode(respBody, &ts))
+}
+
+// MerkleTree computes a merkle tree over a file's contents with the
+// given leaf size on the remote drive. When cache is set the remote
+// stores the tree next to the file and reuses it on later calls.
+func (client *storageRESTClient) MerkleTree(ctx context.Context, volume, path string, leafSize int64, cache bool) (mt MerkleTreeInfo, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTLeafSize, strconv.FormatInt(leafSize, 10))
+	values.Set(storageRESTCacheTree, strconv.FormatBool(cache))
+	respBody, err := client.callGet(ctx, storageRESTMethodMerkleTree, values, nil, -1)
+	if err != nil {
+		return mt, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return mt, toStorageErr(msgp.Decode(respBody, &mt))
+}
+
+// ConvertBitrot re-checksums an object's parts on the remote drive
+// under a new bitrot algorithm, updating only the checksum metadata.
+func (client *storageRESTClient) ConvertBitrot(ctx context.Context, volume, path, versionID string, algo BitrotAlgorithm) error {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTVersionID, versionID)
+	values.Set(storageRESTBitrotAlgo, algo.String())
+	respBody, err := client.call(ctx, storageRESTMethodConvertBitrot, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(respBody)
+	_, err = waitForHTTPResponse(respBody)
+	return toStorageErr(err)
+}
+
+// DriveInitState reports whether the remote drive is serving, still
+// retrying initialization, or failed permanently, with the last
+// initialization error.
+func (client *storageRESTClient) DriveInitState(ctx context.Context) (st DriveInitState, err error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodInitState, nil, nil, -1)
+	if err != nil {
+		return st, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return st, toStorageErr(msgp.Decode(respBody, &st))
+}
+
+// RawPartPaths returns the absolute on-disk paths backing one object
+// version on the remote drive, for tooling running on that host.
+func (client *storageRESTClient) RawPartPaths(ctx context.Context, volume, path, versionID string) (paths RawPartPaths, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTVersionID, versionID)
+	respBody, err := client.callGet(ctx, storageRESTMethodRawPartPaths, values, nil, -1)
+	if err != nil {
+		return paths, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return paths, toStorageErr(msgp.Decode(respBody, &paths))
+}
+
+// StorageEfficiency returns an object's logical size and the physical
+// bytes backing it on the remote drive.
+func (client *storageRESTClient) StorageEfficiency(ctx context.Context, volume, path string) (eff StorageEfficiency, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	respBody, err := client.callGet(ctx, storageRESTMethodStorageEff, values, nil, -1)
+	if err != nil {
+		return eff, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return eff, toStorageErr(msgp.Decode(respBody, &eff))
+}
+
+// LifecycleEval asks the remote drive to evaluate the given lifecycle
+// configuration XML against one object version. Dry-run unless apply
+// is set, in which case an expiry outcome deletes the version.
+func (client *storageRESTClient) LifecycleEval(ctx context.Context, volume, path, versionID string, lcXML []byte, apply bool) (result LifecycleEvalResult, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTVersionID, versionID)
+	values.Set(storageRESTApply, strconv.FormatBool(apply))
+	respBody, err := client.call(ctx, storageRESTMethodLifecycleEval, values, bytes.NewReader(lcXML), int64(len(lcXML)))
+	if err != nil {
+		return result, toStorageErr(err)
+	}
+	defer xhttp.DrainBody(respBody)
+	return result, toStorageErr(msgp.Decode(respBody, &result))
+}
+
+// TaskQueueDepths returns how many background tasks of each type are
+// queued or running against the remote drive.
+func (client *storageRESTClient) TaskQueueDepths(ctx context.Context) (depths TaskQueueDepths, err error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodTaskQueues, nil, nil, -1)
+	if err != nil {
+		return depths, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return depths, toStorageErr(msgp.Decode(respBody, &depths))
+}
+
+// VolExists is a fast existence-only probe for a volume on the
+// remote drive, cheaper than StatVol when only presence matters.
+func (client *storageRESTClient) VolExists(ctx context.Context, volume string) (bool, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	respBody, err := client.callGet(ctx, storageRESTMethodVolExists, values, nil, -1)
+	if err != nil {
+		return false, err
+	}
+	defer xhttp.DrainBody(respBody)
+	buf, err := io.ReadAll(respBody)
+	if err != nil {
+		return false, toStorageErr(err)
+	}
+	return strconv.ParseBool(string(buf))
+}
+
+// DataDirs returns the distinct data directories referenced by the
+// object's versions on the remote drive.
+func (client *storageRESTClient) DataDirs(ctx context.Context, volume, path string) (info DataDirsInfo, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	respBody, err := client.callGet(ctx, storageRESTMethodDataDirs, values, nil, -1)
+	if err != nil {
+		return info, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return info, toStorageErr(msgp.Decode(respBody, &info))
+}
+
+// VersionsSummary returns the number of live versions of an object on
+// the remote drive and their cumulative logical size.
+func (client *storageRESTClient) VersionsSummary(ctx context.Context, volume, path string) (summary VersionsSummary, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	respBody, err := client.callGet(ctx, storageRESTMethodVersionsSumm, values, nil, -1)
+	if err != nil {
+		return summary, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return summary, toStorageErr(msgp.Decode(respBody, &summary))
+}
+
+// ObjectSetDigest returns a compact digest of the objects the remote
+// drive holds under a volume, for diffing against another drive.
+func (client *storageRESTClient) ObjectSetDigest(ctx context.Context, volume string) (digest ObjectSetDigest, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	respBody, err := client.callGet(ctx, storageRESTMethodObjectSet, values, nil, -1)
+	if err != nil {
+		return digest, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return digest, toStorageErr(msgp.Decode(respBody, &digest))
+}
+
+// ReserveVersionID asks the remote node to mint and reserve a unique
+// version-id for the given object path. The reservation expires on
+// the remote side if the upload never finalizes.
+func (client *storageRESTClient) ReserveVersionID(ctx context.Context, volume, path string) (string, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	respBody, err := client.call(ctx, storageRESTMethodReserveVID, values, nil, -1)
+	if err != nil {
+		return "", err
+	}
+	defer xhttp.DrainBody(respBody)
+	buf, err := io.ReadAll(respBody)
+	if err != nil {
+		return "", toStorageErr(err)
+	}
+	return string(buf), nil
+}
+
+// DiskHealthInfo returns the structured health of the remote drive,
+// beyond the bare status code of the legacy health probe.
+func (client *storageRESTClient) DiskHealthInfo(ctx context.Context) (info DiskHealthInfo, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTInfo, "true")
+	respBody, err := client.call(ctx, storageRESTMethodHealth, values, nil, -1)
+	if err != nil {
+		return info, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return info, toStorageErr(msgp.Decode(respBody, &info))
+}
+
+// ReadTransformed streams a file through a server-side transform
+// pipeline, e.g. ["decompress:s2"], applied on the remote node before
+// the bytes hit the wire. The caller must close the returned reader.
+func (client *storageRESTClient) ReadTransformed(ctx context.Context, volume, path string, pipeline []string) (io.ReadCloser, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTPipeline, strings.Join(pipeline, ","))
+	respBody, err := client.callGet(ctx, storageRESTMethodReadTransform, values, nil, -1)
+	if err != nil {
+		return nil, toStorageErr(err)
+	}
+	return respBody, nil
+}
+
+// MetadataDigest returns the rolling digest over the remote drive's
+// object metadata, as last published by its scanner.
+func (client *storageRESTClient) MetadataDigest(ctx context.Context) (digest MetadataDigest, err error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodMetaDigest, nil, nil, -1)
+	if err != nil {
+		return digest, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return digest, toStorageErr(msgp.Decode(respBody, &digest))
+}
+
+// ObjectShardLayout reports which erasure shard of the given object
+// the remote drive holds and the expected shard distribution, for
+// verifying shard placement across a set.
+func (client *storageRESTClient) ObjectShardLayout(ctx context.Context, volume, path, versionID string) (layout ObjectShardLayout, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTVersionID, versionID)
+	respBody, err := client.callGet(ctx, storageRESTMethodShardLayout, values, nil, -1)
+	if err != nil {
+		return layout, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return layout, toStorageErr(msgp.Decode(respBody, &layout))
+}
+
+// ReloadDrive asks the remote node to immediately re-initialize the
+// storage layer of this client's endpoint, e.g. after a physical
+// drive replacement, and reports the resulting init state.
+func (client *storageRESTClient) ReloadDrive(ctx context.Context) (st DriveInitState, err error) {
+	respBody, err := client.call(ctx, storageRESTMethodReloadDrive, nil, nil, -1)
+	if err != nil {
+		return st, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return st, toStorageErr(msgp.Decode(respBody, &st))
+}
+
+// ReadAllHTTP reads a file's full content over the HTTP surface,
+// advertising the payload codecs this node can decode. If the remote
+// chose to compress, the payload is decoded transparently here.
+func (client *storageRESTClient) ReadAllHTTP(ctx context.Context, volume, path string) ([]byte, error) {
+	values := make(url.Values)
+	values.Set(storageRESTDiskID, *client.diskID.Load())
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	header := make(http.Header)
+	header.Set(xhttp.MinIOAcceptEncoding, internodeCodecS2)
+	respBody, respHeader, err := client.restClient.CallWithHeaders(ctx, http.MethodGet, storageRESTMethodReadAll, values, nil, -1, header)
+	if err != nil {
+		return nil, toStorageErr(err)
+	}
+	defer xhttp.DrainBody(respBody)
+	buf, err := io.ReadAll(respBody)
+	if err != nil {
+		return nil, toStorageErr(err)
+	}
+	if respHeader.Get(xhttp.ContentEncoding) == internodeCodecS2 {
+		buf, err = s2.Decode(nil, buf)
+		if err != nil {
+			return nil, toStorageErr(err)
+		}
+	}
+	return buf, nil
+}
+
+// WriteAllHTTP writes a file's full content over the HTTP surface,
+// compressing the payload when it is large enough to be worth it.
+func (client *storageRESTClient) WriteAllHTTP(ctx context.Context, volume, path string, b []byte) error {
+	values := make(url.Values)
+	values.Set(storageRESTDiskID, *client.diskID.Load())
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	var header http.Header
+	if len(b) >= internodeCompressMinSize {
+		b = s2.Encode(nil, b)
+		header = make(http.Header)
+		header.Set(xhttp.ContentEncoding, internodeCodecS2)
+	}
+	respBody, _, err := client.restClient.CallWithHeaders(ctx, http.MethodPost, storageRESTMethodWriteAll, values, bytes.NewReader(b), int64(len(b)), header)
+	defer xhttp.DrainBody(respBody)
+	return toStorageErr(err)
+}
+
+// ScanErrorRate returns the remote drive's scan errors over its
+// sliding window.
+func (client *storageRESTClient) ScanErrorRate(ctx context.Context) (rate ScanErrorRate, err error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodScanErrRate, nil, nil, -1)
+	if err != nil {
+		return rate, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return rate, toStorageErr(msgp.Decode(respBody, &rate))
+}
+
+// FillerStats returns the remote node's keep-alive filler byte
+// counters per handler, optionally resetting them.
+func (client *storageRESTClient) FillerStats(ctx context.Context, reset bool) (stats FillerByteStats, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTReset, strconv.FormatBool(reset))
+	respBody, err := client.callGet(ctx, storageRESTMethodFillerStats, values, nil, -1)
+	if err != nil {
+		return stats, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return stats, toStorageErr(msgp.Decode(respBody, &stats))
+}
+
+// SetSoftDeleteWindow configures how long soft-deleted versions on
+// the remote drive stay recoverable, zero disables soft delete.
+func (client *storageRESTClient) SetSoftDeleteWindow(ctx context.Context, window time.Duration) error {
+	values := make(url.Values)
+	values.Set(storageRESTInterval, window.String())
+	respBody, err := client.call(ctx, storageRESTMethodSetTrashWindow, values, nil, -1)
+	defer xhttp.DrainBody(respBody)
+	return toStorageErr(err)
+}
+
+// RestoreVersion moves a soft-deleted version out of the remote
+// drive's trash area back to its original location.
+func (client *storageRESTClient) RestoreVersion(ctx context.Context, volume, path, versionID string) error {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTVersionID, versionID)
+	respBody, err := client.call(ctx, storageRESTMethodRestoreVersion, values, nil, -1)
+	defer xhttp.DrainBody(respBody)
+	return toStorageErr(err)
+}
+
+// IsLatestVersion reports whether versionID is currently the head
+// version of the object, without fetching the whole version list.
+func (client *storageRESTClient) IsLatestVersion(ctx context.Context, volume, path, versionID string) (bool, error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTVersionID, versionID)
+	respBody, err := client.callGet(ctx, storageRESTMethodIsLatest, values, nil, -1)
+	if err != nil {
+		return false, err
+	}
+	defer xhttp.DrainBody(respBody)
+	buf, err := io.ReadAll(respBody)
+	if err != nil {
+		return false, toStorageErr(err)
+	}
+	return strconv.ParseBool(string(buf))
+}
+
+// ErasureSetLayout returns where the remote drive believes it sits in
+// the erasure-set topology, both as configured on its endpoint and as
+// recorded in its format.json.
+func (client *storageRESTClient) ErasureSetLayout(ctx context.Context) (layout ErasureSetLayout, err error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodSetLayout, nil, nil, -1)
+	if err != nil {
+		return layout, err
+	}
+	defer xhttp.DrainBody(respBody)
+	return layout, toStorageErr(msgp.Decode(respBody, &layout))
+}
+
+// RefreshDiskID asks the remote drive to re-read its disk-id from
+// format.json, bypassing any cached copy, and returns the fresh id.
+func (client *storageRESTClient) RefreshDiskID(ctx context.Context) (string, error) {
+	respBody, err := client.call(ctx, storageRESTMethodRefreshDiskID, nil, nil, -1)
+	if err != nil {
+		return "", err
+	}
+	defer xhttp.DrainBody(respBody)
+	id, err := io.ReadAll(respBody)
+	if err != nil {
+		return "", toStorageErr(err)
+	}
+	return string(id), nil
+}
+
+// GridHandlers returns the grid RPC handlers registered on the remote
+// node, one handler name per line followed by its subroute if any.
+func (client *storageRESTClient) GridHandlers(ctx context.Context) ([]string, error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodGridHandlers, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer xhttp.DrainBody(respBody)
+	b, err := io.ReadAll(respBody)
+	if err != nil {
+		return nil, toStorageErr(err)
+	}
+	out := strings.TrimSuffix(string(b), "\n")
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// ReadRecords returns the contents of a file split on the given
+// delimiter byte, one entry per record including empty ones.
+func (client *storageRESTClient) ReadRecords(ctx context.Context, volume, path string, delim byte) (records [][]byte, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTDelimiter, strconv.Itoa(int(delim)))
+	respBody, err := client.callGet(ctx, storageRESTMethodReadRecords, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer xhttp.DrainBody(respBody)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(waitForHTTPStream(respBody, ioutil.NewDeadlineWriter(pw, globalDriveConfig.GetMaxTimeout())))
+	}()
+	var hdr [4]byte
+	for {
+		if _, err := io.ReadFull(pr, hdr[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			pr.CloseWithError(err)
+			return records, toStorageErr(err)
+		}
+		rec := make([]byte, binary.LittleEndian.Uint32(hdr[:]))
+		if _, err := io.ReadFull(pr, rec); err != nil {
+			pr.CloseWithError(err)
+			return nil, toStorageErr(err)
+		}
+		records = append(records, rec)
+	}
+}
+
+// BitrotErrors returns the bounded list of bitrot errors recently
+// detected on the remote drive, oldest first.
+func (client *storageRESTClient) BitrotErrors(ctx context.Context) (infos []BitrotErrorInfo, err error) {
+	respBody, err := client.callGet(ctx, storageRESTMethodBitrotErrors, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer xhttp.DrainBody(respBody)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(waitForHTTPStream(respBody, ioutil.NewDeadlineWriter(pw, globalDriveConfig.GetMaxTimeout())))
+	}()
+	mr := msgp.NewReader(pr)
+	for {
+		var info BitrotErrorInfo
+		if err := info.DecodeMsg(mr); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			pr.CloseWithError(err)
+			return infos, toStorageErr(err)
+		}
+		infos = append(infos, info)
+	}
+}
+
+// ListIncompleteUploads returns the in-progress multipart uploads in
+// the remote drive's staging area. With both volume and path set only
+// uploads for that object are returned.
+func (client *storageRESTClient) ListIncompleteUploads(ctx context.Context, volume, path string) (uploads []IncompleteUploadInfo, err error) {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	respBody, err := client.callGet(ctx, storageRESTMethodIncompleteUp, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer xhttp.DrainBody(respBody)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(waitForHTTPStream(respBody, ioutil.NewDeadlineWriter(pw, globalDriveConfig.GetMaxTimeout())))
+	}()
+	mr := msgp.NewReader(pr)
+	for {
+		var info IncompleteUploadInfo
+		if err := info.DecodeMsg(mr); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			pr.CloseWithError(err)
+			return uploads, toStorageErr(err)
+		}
+		uploads = append(uploads, info)
+	}
+}
+
+// CleanAbandonedData will read metadata of the object on disk
+// and delete any data directories and inline data that isn't referenced in metadata.
+func (client *storageRESTClient) CleanAbandonedData(ctx context.Context, volume string, path string) error {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
 	values.Set(storageRESTFilePath, path)
 	respBody, err := client.call(ctx, storageRESTMethodCleanAbandoned, values, nil, -1)
 	if err != nil {
@@ -957,6 +2080,39 @@ func (client *storageRESTClient) CleanAbandonedData(ctx context.Context, volume
 	return toStorageErr(err)
 }
 
+// Rehydrate initiates rehydration of a tiered object's data back to
+// local storage on the remote drive's node. It is a no-op when the
+// data is already local or a rehydration is already in progress.
+func (client *storageRESTClient) Rehydrate(ctx context.Context, volume, path, versionID string) error {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+	values.Set(storageRESTVersionID, versionID)
+	respBody, err := client.call(ctx, storageRESTMethodRehydrate, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(respBody)
+	_, err = waitForHTTPResponse(respBody)
+	return toStorageErr(err)
+}
+
+// SwapData exchanges the contents of the latest versions of two objects,
+// each object keeps its name, version ID and modification time.
+func (client *storageRESTClient) SwapData(ctx context.Context, volume, pathA, pathB string) error {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTSrcPath, pathA)
+	values.Set(storageRESTDstPath, pathB)
+	respBody, err := client.call(ctx, storageRESTMethodSwapData, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(respBody)
+	_, err = waitForHTTPResponse(respBody)
+	return toStorageErr(err)
+}
+
 // Close - marks the client as closed.
 func (client *storageRESTClient) Close() error {
 	client.restClient.Close()
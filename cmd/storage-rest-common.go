@@ -17,10 +17,12 @@
 
 package cmd
 
+import "time"
+
 //go:generate msgp -file $GOFILE -unexported
 
 const (
-	storageRESTVersion       = "v63" // Introduce RenamePart and ReadParts API
+	storageRESTVersion       = "v110" // Coded storage error envelope
 	storageRESTVersionPrefix = SlashSeparator + storageRESTVersion
 	storageRESTPrefix        = minioReservedBucketPath + "/storage"
 )
@@ -45,6 +47,48 @@ const (
 	storageRESTMethodCleanAbandoned = "/cln"
 	storageRESTMethodDeleteBulk     = "/dblk"
 	storageRESTMethodReadParts      = "/rps"
+	storageRESTMethodListOrphans    = "/lorph"
+	storageRESTMethodReadWeakETag   = "/rwetag"
+	storageRESTMethodSetFsyncPolicy = "/sfsp"
+	storageRESTMethodGetFsyncPolicy = "/gfsp"
+	storageRESTMethodOpenReadSess   = "/orsess"
+	storageRESTMethodReadSess       = "/rsess"
+	storageRESTMethodCloseReadSess  = "/crsess"
+	storageRESTMethodSwapData       = "/swpd"
+	storageRESTMethodBitrotErrors   = "/brerrs"
+	storageRESTMethodReadRecords    = "/rrecs"
+	storageRESTMethodGridHandlers   = "/ghandlers"
+	storageRESTMethodRefreshDiskID  = "/rdid"
+	storageRESTMethodObjectTimes    = "/otime"
+	storageRESTMethodMerkleTree     = "/mtree"
+	storageRESTMethodInitState      = "/istate"
+	storageRESTMethodConvertBitrot  = "/cbitrot"
+	storageRESTMethodIncompleteUp   = "/iuplds"
+	storageRESTMethodSetScanBudget  = "/sscanb"
+	storageRESTMethodGetScanBudget  = "/gscanb"
+	storageRESTMethodMutateMeta     = "/mmeta"
+	storageRESTMethodSetTraceRate   = "/strate"
+	storageRESTMethodGetTraceRate   = "/gtrate"
+	storageRESTMethodSetLayout      = "/eslayout"
+	storageRESTMethodIsLatest       = "/islatest"
+	storageRESTMethodRestoreVersion = "/restorev"
+	storageRESTMethodSetTrashWindow = "/strashw"
+	storageRESTMethodDataDirs       = "/ddirs"
+	storageRESTMethodVolExists      = "/vexists"
+	storageRESTMethodTaskQueues     = "/tqdepth"
+	storageRESTMethodLifecycleEval  = "/lceval"
+	storageRESTMethodRawPartPaths   = "/ppaths"
+	storageRESTMethodStorageEff     = "/seff"
+	storageRESTMethodVersionsSumm   = "/vsumm"
+	storageRESTMethodObjectSet      = "/oset"
+	storageRESTMethodFillerStats    = "/fstats"
+	storageRESTMethodRehydrate      = "/rehyd"
+	storageRESTMethodScanErrRate    = "/serate"
+	storageRESTMethodReadTransform  = "/rtrans"
+	storageRESTMethodReserveVID     = "/rsvvid"
+	storageRESTMethodReloadDrive    = "/rlddrv"
+	storageRESTMethodShardLayout    = "/oshards"
+	storageRESTMethodMetaDigest     = "/mdigest"
 )
 
 const (
@@ -72,8 +116,59 @@ const (
 	storageRESTOrigVolume       = "ovol"
 	storageRESTInclFreeVersions = "incl-fv"
 	storageRESTRange            = "rng"
+	storageRESTInterval         = "ival"
+	storageRESTSessionID        = "sess"
+	storageRESTDelimiter        = "delim"
+	storageRESTLeafSize         = "leaf"
+	storageRESTCacheTree        = "ctree"
+	storageRESTShardSize        = "ssize"
+	storageRESTEncoding         = "enc"
+	storageRESTSniff            = "sniff"
+	storageRESTApply            = "apply"
+	storageRESTCreate           = "create"
+	storageRESTDeadline         = "dl"
+	storageRESTReset            = "reset"
+	storageRESTPipeline         = "pipe"
+	storageRESTInfo             = "info"
+	storageRESTBudget           = "budget"
+	storageRESTToken            = "token"
+	storageRESTProgress         = "progress"
+	storageRESTIfModSig         = "ifsig"
 )
 
+// readVersionsMaxBatch caps how many entries a single batched
+// ReadVersions request may carry, keeping one batch from hogging a
+// drive for too long.
+const readVersionsMaxBatch = 1000
+
+// ReadVersionsEntry identifies one version to read in a batched
+// ReadVersions request. The boolean fields mirror ReadOptions.
+type ReadVersionsEntry struct {
+	Volume           string `msg:"v"`
+	FilePath         string `msg:"p"`
+	VersionID        string `msg:"id"`
+	InclFreeVersions bool   `msg:"fv"`
+	ReadData         bool   `msg:"rd"`
+	Healing          bool   `msg:"h"`
+}
+
+// ReadVersionsReq is a batched ReadVersion request, amortizing RPC
+// overhead when many versions are read back-to-back.
+type ReadVersionsReq struct {
+	DiskID  string              `msg:"did"`
+	Entries []ReadVersionsEntry `msg:"e"`
+}
+
+// ReadVersionsResp is one result of a batched ReadVersions request.
+// Responses are streamed strictly in input order, one per entry, so
+// callers correlate them to requests by position or by Idx. A failed
+// entry carries its error string and does not abort the batch.
+type ReadVersionsResp struct {
+	Idx int       `msg:"i"` // index of the entry in the request batch
+	Err string    `msg:"e"` // error string, empty on success
+	FI  *FileInfo `msg:"f"` // version metadata, nil on error
+}
+
 type nsScannerOptions struct {
 	DiskID   string          `msg:"id"`
 	ScanMode int             `msg:"m"`
@@ -84,3 +179,284 @@ type nsScannerResp struct {
 	Update *dataUsageEntry `msg:"u"`
 	Final  *dataUsageCache `msg:"f"`
 }
+
+// ObjectTimestamps holds the derived creation and last modification
+// times of an object, computed server-side from its version list.
+type ObjectTimestamps struct {
+	Created  time.Time `msg:"c"` // modtime of the earliest version
+	Modified time.Time `msg:"m"` // modtime of the latest version
+}
+
+// Supported MetaMutationOp operations.
+const (
+	metaMutationSet    = "set"
+	metaMutationDelete = "del"
+)
+
+// MetaMutationOp is a single operation in a transactional metadata
+// update.
+type MetaMutationOp struct {
+	Op    string `msg:"o"` // metaMutationSet or metaMutationDelete
+	Key   string `msg:"k"` // metadata key the op applies to
+	Value string `msg:"v"` // new value, ignored for deletes
+}
+
+// MetaMutationReq bundles the metadata mutations to apply to one
+// version all-or-nothing.
+type MetaMutationReq struct {
+	Volume    string           `msg:"v"`
+	FilePath  string           `msg:"p"`
+	VersionID string           `msg:"id"`
+	Ops       []MetaMutationOp `msg:"ops"`
+}
+
+// MetaMutationResp returns the metadata resulting from a successful
+// transactional apply.
+type MetaMutationResp struct {
+	Metadata map[string]string `msg:"m"`
+}
+
+// IncompleteUploadInfo describes one in-progress multipart upload
+// found in a drive's staging area.
+type IncompleteUploadInfo struct {
+	ShaDir    string    `msg:"d"`  // sha256 of "bucket/object" the upload belongs to
+	UploadID  string    `msg:"id"` // upload id directory name
+	Parts     int       `msg:"pc"` // parts uploaded so far
+	Initiated time.Time `msg:"t"`  // when the upload was initiated
+}
+
+// DriveInitState describes where a drive is in the asynchronous
+// initialization performed by registerStorageRESTHandlers.
+type DriveInitState struct {
+	Endpoint string `msg:"e"` // endpoint of the drive
+	State    string `msg:"s"` // driveInitReady, driveInitRetrying or driveInitFailed
+	LastErr  string `msg:"l"` // last initialization error, if any
+}
+
+// DeleteVersionsStreamResult reports the outcome of one entry of a
+// DeleteVersions batch as it completes. Err is empty on success; the
+// record itself is framed by the response stream, so a successful
+// delete is never confused with the stream's filler bytes.
+type DeleteVersionsStreamResult struct {
+	Idx int    `msg:"i"` // index of the entry in the request batch
+	Err string `msg:"e"` // error string, empty on success
+}
+
+// RawPartPaths lists the absolute on-disk paths backing one object
+// version, for out-of-band tooling running on the same host. Inline
+// versions have no part files, only the metadata file.
+type RawPartPaths struct {
+	XLMeta string   `msg:"m"` // absolute path of the xl.meta file
+	Parts  []string `msg:"p"` // absolute paths of the part files, in part order
+}
+
+// ObjectSetDigest is a compact summary of the objects a drive holds
+// under one volume, for diffing a drive that was offline against a
+// healthy peer. Entries are sorted per-object hashes over the object
+// path and its version ids, so two digests are diffed with a single
+// merge pass; Digest is the xor of all entries, an order-independent
+// fingerprint of the whole set.
+type ObjectSetDigest struct {
+	Count   int      `msg:"c"` // number of objects summarized
+	Digest  uint64   `msg:"d"` // xor of all entry hashes
+	Entries []uint64 `msg:"e"` // sorted per-object hashes
+}
+
+// VersionsSummary reports how many versions an object has and their
+// cumulative logical size, computed from a single metadata read.
+type VersionsSummary struct {
+	Count     int   `msg:"c"` // number of live versions
+	TotalSize int64 `msg:"s"` // sum of logical sizes across versions
+}
+
+// StorageEfficiency reports how many physical bytes on this drive
+// back an object's logical bytes. Physical size comes from a stat of
+// xl.meta and the part files, so erasure overhead, block alignment and
+// compression all show up as the gap between the two figures.
+type StorageEfficiency struct {
+	LogicalSize  int64   `msg:"l"` // sum of logical sizes of live versions
+	PhysicalSize int64   `msg:"p"` // bytes consumed on this drive
+	Ratio        float64 `msg:"r"` // physical over logical, 0 when no logical bytes
+}
+
+// StatPartsReq identifies the parts of one object to stat in a
+// single call, by part number relative to the object path.
+type StatPartsReq struct {
+	DiskID string `msg:"did"`
+	Volume string `msg:"v"`
+	Path   string `msg:"p"`
+	Parts  []int  `msg:"n"`
+}
+
+// StatPartResult is one slot of a StatPartsResp. A missing part fills
+// Err without failing the whole call.
+type StatPartResult struct {
+	Number  int       `msg:"n"`
+	Size    int64     `msg:"s"`
+	ModTime time.Time `msg:"m"`
+	Exists  bool      `msg:"e"`
+	Err     string    `msg:"err"`
+}
+
+// StatPartsResp carries one result per requested part number, in
+// input order.
+type StatPartsResp struct {
+	Results []StatPartResult `msg:"r"`
+}
+
+// DiskHealthInfo is the structured HealthHandler response, letting
+// orchestration distinguish a degraded drive from a healthy one
+// before deciding to drain a node.
+type DiskHealthInfo struct {
+	DiskID      string    `msg:"id"`
+	Faulty      bool      `msg:"f"`  // drive is currently marked faulty
+	LastSuccess time.Time `msg:"ls"` // last successful I/O, zero when unknown
+	Rotational  bool      `msg:"rot"`
+	ODirect     bool      `msg:"od"` // drive supports O_DIRECT
+}
+
+// ScanErrorRate reports a drive's scan errors over a sliding window,
+// so monitoring can alert on a rising trend instead of a lifetime
+// total.
+type ScanErrorRate struct {
+	WindowMinutes int     `msg:"w"` // width of the sliding window
+	Total         uint64  `msg:"t"` // errors inside the window
+	PerMinute     float64 `msg:"r"` // average errors per minute
+}
+
+// FillerByteStats reports how many keep-alive filler bytes each
+// long-running handler has sent since the counters were last reset.
+// A high rate points at slow drives stalling responses.
+type FillerByteStats struct {
+	Counts map[string]uint64 `msg:"c"`
+}
+
+// Lifecycle actions reported by LifecycleEvalResult.
+const (
+	lifecycleEvalExpire     = "expire"
+	lifecycleEvalTransition = "transition"
+	lifecycleEvalNone       = "none"
+)
+
+// LifecycleEvalResult is the outcome of evaluating lifecycle rules
+// against one object version without necessarily applying it.
+type LifecycleEvalResult struct {
+	Action string    `msg:"a"` // expire, transition or none
+	RuleID string    `msg:"r"` // id of the rule that matched, if any
+	Due    time.Time `msg:"d"` // when the action is (or was) due
+}
+
+// TaskQueueDepths reports how many background tasks of each type are
+// queued or running against a drive.
+type TaskQueueDepths struct {
+	Depths map[string]int64 `msg:"d"` // depth keyed by task type
+}
+
+// DataDirsInfo lists the distinct data directories referenced by an
+// object's versions, ideally one per live version. More suggests
+// fragmentation from interrupted overwrites.
+type DataDirsInfo struct {
+	Count int      `msg:"c"` // number of distinct data directories
+	Dirs  []string `msg:"d"` // data-dir ids, in version order
+}
+
+// ErasureSetLayout reports where a drive believes it sits in the
+// erasure-set topology, combining the configured endpoint indices
+// with the placement recorded in the drive's format.json.
+type ErasureSetLayout struct {
+	PoolIdx       int    `msg:"pi"` // pool index of the configured endpoint
+	SetIdx        int    `msg:"si"` // set index of the configured endpoint
+	DiskIdx       int    `msg:"di"` // disk index of the configured endpoint
+	FormatSetIdx  int    `msg:"fs"` // set index of the drive's UUID in format.json, -1 if absent
+	FormatDiskIdx int    `msg:"fd"` // disk index of the drive's UUID in format.json, -1 if absent
+	SetCount      int    `msg:"sc"` // number of erasure sets in format.json
+	SetDriveCount int    `msg:"sd"` // drives per erasure set in format.json
+	DiskID        string `msg:"id"` // the drive's UUID from format.json
+}
+
+// MerkleTreeInfo holds a merkle tree computed over a file's contents.
+// Leaf hashes are retained so corruption can be localized to a single
+// leaf without re-hashing the whole file.
+type MerkleTreeInfo struct {
+	LeafSize int64    `msg:"l"` // number of bytes hashed per leaf
+	Root     []byte   `msg:"r"` // root hash over all leaves
+	Leaves   [][]byte `msg:"n"` // per-leaf hashes, in file order
+}
+
+// BitrotErrorInfo describes a single bitrot detection recorded by a
+// drive during part verification.
+type BitrotErrorInfo struct {
+	Volume   string    `msg:"v"`  // volume the corrupted part belongs to
+	Path     string    `msg:"p"`  // object path of the corrupted part
+	Part     int       `msg:"pn"` // part number that failed verification
+	Detected time.Time `msg:"t"`  // when the corruption was detected
+}
+
+// OrphanedDataInfo describes a data directory found on disk that is
+// not referenced by any version in the object's xl.meta.
+type OrphanedDataInfo struct {
+	Path    string    `msg:"p"` // object path of the orphaned data dir
+	Size    int64     `msg:"s"` // size as reported by stat
+	ModTime time.Time `msg:"m"` // last modification of the data dir
+}
+
+// ListDirBudgetResult is a single message of the budgeted ListDir
+// stream. Entries arrive in sorted batches; a message with Truncated
+// set ends the stream early and carries a continuation token.
+type ListDirBudgetResult struct {
+	Entries   []string `msg:"e"` // sorted batch of directory entries
+	Truncated bool     `msg:"t"` // set when the time budget expired
+	Token     string   `msg:"c"` // resume after this entry, set when truncated
+}
+
+// ObjectShardLayout reports which erasure shard of an object this
+// drive holds and where the remaining shards are expected to live, so
+// tooling can verify shard placement across a set.
+type ObjectShardLayout struct {
+	ShardIndex   int   `msg:"i"`  // 1-based erasure shard index held by this drive
+	DataBlocks   int   `msg:"d"`  // number of data shards
+	ParityBlocks int   `msg:"pb"` // number of parity shards
+	Distribution []int `msg:"ds"` // shard rotation across the set's drives
+	PoolIdx      int   `msg:"pl"` // this drive's pool in the configured topology
+	SetIdx       int   `msg:"s"`  // this drive's erasure set
+	DiskIdx      int   `msg:"x"`  // this drive's position within the set
+}
+
+// VerifyFileProgress is one record of a progress-streamed VerifyFile
+// response. Interim records report how far the bitrot scan has come;
+// the terminal record has Done set and carries the final result.
+type VerifyFileProgress struct {
+	Done      bool            `msg:"d"` // set on the terminal record
+	Part      int             `msg:"p"` // index of the part just verified
+	BytesDone int64           `msg:"b"` // cumulative shard bytes verified
+	Resp      *CheckPartsResp `msg:"r"` // final result, only on the terminal record
+}
+
+// MetadataDigest is a rolling digest over all object metadata a drive
+// holds, maintained by the scanner, so two nodes can compare a single
+// value to detect divergence before resorting to a detailed diff. A
+// zero Updated means no scan has completed on the drive yet.
+type MetadataDigest struct {
+	Digest  uint64    `msg:"d"` // xor of per-object metadata hashes
+	Objects uint64    `msg:"n"` // objects folded into the digest
+	Updated time.Time `msg:"u"` // when a scan last refreshed the digest
+}
+
+// DiskSnapshotReq selects what a disk snapshot should carry: disk
+// metrics and the set of volumes to stat alongside the DiskInfo.
+type DiskSnapshotReq struct {
+	DiskID  string   `msg:"id"`
+	Metrics bool     `msg:"m"`
+	Volumes []string `msg:"vs"` // volumes to stat in the same snapshot
+}
+
+// DiskSnapshot couples a drive's DiskInfo with the VolInfo of a
+// requested set of volumes, gathered from a single resolution of the
+// underlying storage so the fields describe one moment rather than
+// two separate calls. Volumes and VolErrs are positional with the
+// request, a non-empty VolErrs entry carries that volume's failure.
+type DiskSnapshot struct {
+	Disk    DiskInfo  `msg:"d"`
+	Volumes []VolInfo `msg:"v"`
+	VolErrs []string  `msg:"e"`
+}
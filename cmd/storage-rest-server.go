@@ -19,26 +19,36 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
+	"os"
 	"os/user"
 	"path"
 	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/klauspost/compress/s2"
 	"github.com/minio/minio/internal/grid"
 	"github.com/tinylib/msgp/msgp"
 
 	jwtreq "github.com/golang-jwt/jwt/v4/request"
 	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/config"
 	xhttp "github.com/minio/minio/internal/http"
 	xioutil "github.com/minio/minio/internal/ioutil"
@@ -46,6 +56,7 @@ import (
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/mux"
 	xnet "github.com/minio/pkg/v3/net"
+	"github.com/zeebo/xxh3"
 )
 
 var errDiskStale = errors.New("drive stale")
@@ -57,13 +68,16 @@ type storageRESTServer struct {
 
 var (
 	storageCheckPartsRPC       = grid.NewSingleHandler[*CheckPartsHandlerParams, *CheckPartsResp](grid.HandlerCheckParts2, func() *CheckPartsHandlerParams { return &CheckPartsHandlerParams{} }, func() *CheckPartsResp { return &CheckPartsResp{} })
+	storageStatPartsRPC        = grid.NewSingleHandler[*StatPartsReq, *StatPartsResp](grid.HandlerStatParts, func() *StatPartsReq { return &StatPartsReq{} }, func() *StatPartsResp { return &StatPartsResp{} })
 	storageDeleteFileRPC       = grid.NewSingleHandler[*DeleteFileHandlerParams, grid.NoPayload](grid.HandlerDeleteFile, func() *DeleteFileHandlerParams { return &DeleteFileHandlerParams{} }, grid.NewNoPayload).AllowCallRequestPool(true)
 	storageDeleteVersionRPC    = grid.NewSingleHandler[*DeleteVersionHandlerParams, grid.NoPayload](grid.HandlerDeleteVersion, func() *DeleteVersionHandlerParams { return &DeleteVersionHandlerParams{} }, grid.NewNoPayload)
 	storageDiskInfoRPC         = grid.NewSingleHandler[*DiskInfoOptions, *DiskInfo](grid.HandlerDiskInfo, func() *DiskInfoOptions { return &DiskInfoOptions{} }, func() *DiskInfo { return &DiskInfo{} }).WithSharedResponse().AllowCallRequestPool(true)
+	storageDiskSnapshotRPC     = grid.NewSingleHandler[*DiskSnapshotReq, *DiskSnapshot](grid.HandlerDiskSnapshot, func() *DiskSnapshotReq { return &DiskSnapshotReq{} }, func() *DiskSnapshot { return &DiskSnapshot{} }).WithSharedResponse()
 	storageNSScannerRPC        = grid.NewStream[*nsScannerOptions, grid.NoPayload, *nsScannerResp](grid.HandlerNSScanner, func() *nsScannerOptions { return &nsScannerOptions{} }, nil, func() *nsScannerResp { return &nsScannerResp{} })
 	storageReadAllRPC          = grid.NewSingleHandler[*ReadAllHandlerParams, *grid.Bytes](grid.HandlerReadAll, func() *ReadAllHandlerParams { return &ReadAllHandlerParams{} }, grid.NewBytes).AllowCallRequestPool(true)
 	storageWriteAllRPC         = grid.NewSingleHandler[*WriteAllHandlerParams, grid.NoPayload](grid.HandlerWriteAll, func() *WriteAllHandlerParams { return &WriteAllHandlerParams{} }, grid.NewNoPayload)
 	storageReadVersionRPC      = grid.NewSingleHandler[*grid.MSS, *FileInfo](grid.HandlerReadVersion, grid.NewMSS, func() *FileInfo { return &FileInfo{} })
+	storageReadVersionsRPC     = grid.NewStream[*ReadVersionsReq, grid.NoPayload, *ReadVersionsResp](grid.HandlerReadVersions, func() *ReadVersionsReq { return &ReadVersionsReq{} }, nil, func() *ReadVersionsResp { return &ReadVersionsResp{} }).WithOutCapacity(1)
 	storageReadXLRPC           = grid.NewSingleHandler[*grid.MSS, *RawFileInfo](grid.HandlerReadXL, grid.NewMSS, func() *RawFileInfo { return &RawFileInfo{} })
 	storageRenameDataRPC       = grid.NewSingleHandler[*RenameDataHandlerParams, *RenameDataResp](grid.HandlerRenameData2, func() *RenameDataHandlerParams { return &RenameDataHandlerParams{} }, func() *RenameDataResp { return &RenameDataResp{} })
 	storageRenameDataInlineRPC = grid.NewSingleHandler[*RenameDataInlineHandlerParams, *RenameDataResp](grid.HandlerRenameDataInline, newRenameDataInlineHandlerParams, func() *RenameDataResp { return &RenameDataResp{} }).AllowCallRequestPool(false)
@@ -73,6 +87,8 @@ var (
 	storageUpdateMetadataRPC   = grid.NewSingleHandler[*MetadataHandlerParams, grid.NoPayload](grid.HandlerUpdateMetadata, func() *MetadataHandlerParams { return &MetadataHandlerParams{} }, grid.NewNoPayload)
 	storageWriteMetadataRPC    = grid.NewSingleHandler[*MetadataHandlerParams, grid.NoPayload](grid.HandlerWriteMetadata, func() *MetadataHandlerParams { return &MetadataHandlerParams{} }, grid.NewNoPayload)
 	storageListDirRPC          = grid.NewStream[*grid.MSS, grid.NoPayload, *ListDirResult](grid.HandlerListDir, grid.NewMSS, nil, func() *ListDirResult { return &ListDirResult{} }).WithOutCapacity(1)
+	storageListDirBudgetRPC    = grid.NewStream[*grid.MSS, grid.NoPayload, *ListDirBudgetResult](grid.HandlerListDirBudget, grid.NewMSS, nil, func() *ListDirBudgetResult { return &ListDirBudgetResult{} }).WithOutCapacity(1)
+	storageListDirChunkedRPC   = grid.NewStream[*grid.MSS, grid.NoPayload, *ListDirResult](grid.HandlerListDirChunked, grid.NewMSS, nil, func() *ListDirResult { return &ListDirResult{} }).WithOutCapacity(1)
 )
 
 func getStorageViaEndpoint(endpoint Endpoint) StorageAPI {
@@ -81,35 +97,125 @@ func getStorageViaEndpoint(endpoint Endpoint) StorageAPI {
 	if len(globalLocalSetDrives) == 0 {
 		return globalLocalDrivesMap[endpoint.String()]
 	}
-	return globalLocalSetDrives[endpoint.PoolIdx][endpoint.SetIdx][endpoint.DiskIdx]
+	// A stale or malformed request during reconfiguration may carry
+	// out-of-range indices, treat it as drive not found instead of
+	// panicking.
+	if endpoint.PoolIdx < 0 || endpoint.PoolIdx >= len(globalLocalSetDrives) {
+		return nil
+	}
+	sets := globalLocalSetDrives[endpoint.PoolIdx]
+	if endpoint.SetIdx < 0 || endpoint.SetIdx >= len(sets) {
+		return nil
+	}
+	drives := sets[endpoint.SetIdx]
+	if endpoint.DiskIdx < 0 || endpoint.DiskIdx >= len(drives) {
+		return nil
+	}
+	return drives[endpoint.DiskIdx]
 }
 
 func (s *storageRESTServer) getStorage() StorageAPI {
 	return getStorageViaEndpoint(s.endpoint)
 }
 
-func (s *storageRESTServer) writeErrorResponse(w http.ResponseWriter, err error) {
-	err = unwrapAll(err)
+// storageStatusCode classifies a storage error into the HTTP status
+// reported to internode callers. The distinction matters to the
+// erasure layer: a full drive should reroute the write, a faulty
+// drive should be taken out of rotation, while a permission problem
+// is not worth retrying at all.
+func storageStatusCode(err error) int {
 	switch err {
 	case errDiskStale:
-		w.WriteHeader(http.StatusPreconditionFailed)
-	case errFileNotFound, errFileVersionNotFound:
-		w.WriteHeader(http.StatusNotFound)
+		return http.StatusPreconditionFailed
+	case errFileNotFound, errFileVersionNotFound, errFileVersionNotModified:
+		return http.StatusNotFound
 	case errInvalidAccessKeyID, errAccessKeyDisabled, errNoAuthToken, errMalformedAuth, errAuthentication, errSkewedAuthTime:
-		w.WriteHeader(http.StatusUnauthorized)
+		return http.StatusUnauthorized
+	case errDiskFull:
+		return http.StatusInsufficientStorage
+	case errFaultyDisk, errFaultyRemoteDisk, errDriveBusy:
+		return http.StatusServiceUnavailable
 	case context.Canceled, context.DeadlineExceeded:
-		w.WriteHeader(499)
+		return 499
 	default:
-		w.WriteHeader(http.StatusForbidden)
+		return http.StatusForbidden
 	}
-	w.Write([]byte(err.Error()))
+}
+
+func (s *storageRESTServer) writeErrorResponse(w http.ResponseWriter, err error) {
+	err = unwrapAll(err)
+	w.WriteHeader(storageStatusCode(err))
+	w.Write([]byte(storageErrEncode(err)))
 }
 
 // DefaultSkewTime - skew time is 15 minutes between minio peers.
 const DefaultSkewTime = 15 * time.Minute
 
+// validatedTokenCacheSize bounds how many distinct tokens are
+// remembered; internode peers reuse a handful of tokens, so a small
+// cache is enough.
+const validatedTokenCacheSize = 100
+
+// validatedTokenCache remembers tokens that recently passed
+// validateStorageRequestToken, so repeated identical tokens skip the
+// HMAC verification. Entries are bound to the credentials they were
+// validated against, so a credential rotation empties the cache.
+// Tokens that failed validation are never cached, and the per-request
+// skew-time check is unaffected.
+type validatedTokenCache struct {
+	mu        sync.Mutex
+	accessKey string
+	secretKey string
+	tokens    map[string]time.Time // token -> last use, for eviction
+}
+
+// checkCred empties the cache when the active credentials changed.
+// Called with the lock held.
+func (c *validatedTokenCache) checkCred() {
+	if c.accessKey != globalActiveCred.AccessKey || c.secretKey != globalActiveCred.SecretKey {
+		c.accessKey = globalActiveCred.AccessKey
+		c.secretKey = globalActiveCred.SecretKey
+		c.tokens = make(map[string]time.Time, validatedTokenCacheSize)
+	}
+}
+
+func (c *validatedTokenCache) lookup(token string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkCred()
+	if _, ok := c.tokens[token]; !ok {
+		return false
+	}
+	c.tokens[token] = time.Now()
+	return true
+}
+
+func (c *validatedTokenCache) store(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkCred()
+	if len(c.tokens) >= validatedTokenCacheSize {
+		// Evict the least recently used token.
+		var oldest string
+		var oldestUse time.Time
+		for t, use := range c.tokens {
+			if oldest == "" || use.Before(oldestUse) {
+				oldest, oldestUse = t, use
+			}
+		}
+		delete(c.tokens, oldest)
+	}
+	c.tokens[token] = time.Now()
+}
+
+var globalValidatedTokens = &validatedTokenCache{}
+
 // validateStorageRequestToken will validate the token against the provided audience.
 func validateStorageRequestToken(token string) error {
+	if globalValidatedTokens.lookup(token) {
+		return nil
+	}
+
 	claims := xjwt.NewStandardClaims()
 	if err := xjwt.ParseWithStandardClaims(token, claims, []byte(globalActiveCred.SecretKey)); err != nil {
 		return errAuthentication
@@ -120,6 +226,7 @@ func validateStorageRequestToken(token string) error {
 		return errAuthentication
 	}
 
+	globalValidatedTokens.store(token)
 	return nil
 }
 
@@ -183,6 +290,18 @@ func (s *storageRESTServer) IsValid(w http.ResponseWriter, r *http.Request) bool
 		return false
 	}
 
+	// Defense in depth: reject volume or path parameters that could
+	// escape the volume root before any disk operation runs.
+	if err := checkStoragePaths(
+		r.Form.Get(storageRESTVolume), r.Form.Get(storageRESTSrcVolume),
+		r.Form.Get(storageRESTDstVolume), r.Form.Get(storageRESTOrigVolume),
+		r.Form.Get(storageRESTFilePath), r.Form.Get(storageRESTSrcPath),
+		r.Form.Get(storageRESTDstPath), r.Form.Get(storageRESTDirPath),
+	); err != nil {
+		s.writeErrorResponse(w, err)
+		return false
+	}
+
 	diskID := r.Form.Get(storageRESTDiskID)
 	if diskID == "" {
 		// Request sent empty disk-id, we allow the request
@@ -191,7 +310,13 @@ func (s *storageRESTServer) IsValid(w http.ResponseWriter, r *http.Request) bool
 		return true
 	}
 
-	storedDiskID, err := s.getStorage().GetDiskID()
+	storage := s.getStorage()
+	if storage == nil {
+		s.writeErrorResponse(w, errDiskNotFound)
+		return false
+	}
+
+	storedDiskID, err := storage.GetDiskID()
 	if err != nil {
 		s.writeErrorResponse(w, err)
 		return false
@@ -206,6 +331,36 @@ func (s *storageRESTServer) IsValid(w http.ResponseWriter, r *http.Request) bool
 	return true
 }
 
+// isSecureStoragePath reports whether a caller-supplied volume or file
+// path is safe to hand to the storage layer: no null bytes, not
+// absolute, and no ".." path components that could climb out of the
+// volume root. Dots inside a name ("a..b", "file.tar.gz") are fine.
+// The storage layer has checks of its own; this is defense in depth
+// so one buggy or compromised caller cannot point a node outside its
+// tree. Empty values are allowed, handlers use them for "not set".
+func isSecureStoragePath(p string) bool {
+	if strings.ContainsRune(p, 0) || strings.HasPrefix(p, SlashSeparator) || strings.HasPrefix(p, `\`) {
+		return false
+	}
+	for _, part := range strings.FieldsFunc(p, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// checkStoragePaths applies isSecureStoragePath to every given value,
+// errInvalidArgument at the first offender.
+func checkStoragePaths(paths ...string) error {
+	for _, p := range paths {
+		if !isSecureStoragePath(p) {
+			return errInvalidArgument
+		}
+	}
+	return nil
+}
+
 // checkID - check if the disk-id in the request corresponds to the underlying disk.
 func (s *storageRESTServer) checkID(wantID string) bool {
 	if s.getStorage() == nil {
@@ -228,7 +383,34 @@ func (s *storageRESTServer) checkID(wantID string) bool {
 
 // HealthHandler handler checks if disk is stale
 func (s *storageRESTServer) HealthHandler(w http.ResponseWriter, r *http.Request) {
-	s.IsValid(w, r)
+	if !s.IsValid(w, r) {
+		return
+	}
+	if ok, _ := strconv.ParseBool(r.Form.Get(storageRESTInfo)); !ok {
+		// Legacy probe, the status code is the whole answer.
+		return
+	}
+
+	storage := s.getStorage()
+	var info DiskHealthInfo
+	info.DiskID, _ = storage.GetDiskID()
+
+	// Pull the health and support flags from the same place DiskInfo
+	// reads them.
+	var xs *xlStorage
+	switch st := storage.(type) {
+	case *xlStorageDiskIDCheck:
+		info.Faulty = st.health.isFaulty()
+		info.LastSuccess = time.Unix(0, atomic.LoadInt64(&st.health.lastSuccess))
+		xs = st.storage
+	case *xlStorage:
+		xs = st
+	}
+	if xs != nil {
+		info.Rotational = xs.rotational
+		info.ODirect = xs.oDirect
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &info))
 }
 
 // DiskInfoHandler - returns disk info.
@@ -243,6 +425,37 @@ func (s *storageRESTServer) DiskInfoHandler(opts *DiskInfoOptions) (*DiskInfo, *
 	return &info, nil
 }
 
+// DiskSnapshotHandler - returns the drive's DiskInfo together with
+// the VolInfo of the requested volumes in one response. Both reads go
+// through a single resolution of the underlying storage, so the
+// snapshot describes one disk at one moment instead of whatever two
+// separate DiskInfo/StatVol calls happen to see. The individual
+// handlers remain for callers that need only one side.
+func (s *storageRESTServer) DiskSnapshotHandler(req *DiskSnapshotReq) (*DiskSnapshot, *grid.RemoteErr) {
+	if !s.checkID(req.DiskID) {
+		return nil, grid.NewRemoteErr(errDiskNotFound)
+	}
+	storage := s.getStorage()
+	info, err := storage.DiskInfo(context.Background(), DiskInfoOptions{DiskID: req.DiskID, Metrics: req.Metrics})
+	if err != nil {
+		info.Error = err.Error()
+	}
+	resp := &DiskSnapshot{
+		Disk:    info,
+		Volumes: make([]VolInfo, len(req.Volumes)),
+		VolErrs: make([]string, len(req.Volumes)),
+	}
+	for i, volume := range req.Volumes {
+		vi, err := storage.StatVol(context.Background(), volume)
+		if err != nil {
+			resp.VolErrs[i] = err.Error()
+			continue
+		}
+		resp.Volumes[i] = vi
+	}
+	return resp, nil
+}
+
 func (s *storageRESTServer) NSScannerHandler(ctx context.Context, params *nsScannerOptions, out chan<- *nsScannerResp) *grid.RemoteErr {
 	if !s.checkID(params.DiskID) {
 		return grid.NewRemoteErr(errDiskNotFound)
@@ -251,6 +464,12 @@ func (s *storageRESTServer) NSScannerHandler(ctx context.Context, params *nsScan
 		return grid.NewRemoteErrString("NSScannerHandler: provided cache is nil")
 	}
 
+	gate := driveHeavyGateFor(s.endpoint.String())
+	if err := gate.acquire(ctx); err != nil {
+		return grid.NewRemoteErr(err)
+	}
+	defer gate.release()
+
 	// Collect updates, stream them before the full cache is sent.
 	updates := make(chan dataUsageEntry, 1)
 	var wg sync.WaitGroup
@@ -299,19 +518,78 @@ func (s *storageRESTServer) MakeVolBulkHandler(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// parseStorageDeadline parses a caller-supplied absolute deadline,
+// unix-nanos or RFC3339. The value is advisory; empty or unparsable
+// values report no deadline.
+func parseStorageDeadline(v string) (time.Time, bool) {
+	if v == "" {
+		return time.Time{}, false
+	}
+	if nanos, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(0, nanos), true
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// gridContext derives a context from the deadline carried in grid
+// params, so a slow disk operation is abandoned once the caller has
+// given up. Grid single handlers receive no caller context otherwise.
+func gridContext(params *grid.MSS) (context.Context, context.CancelFunc) {
+	if deadline, ok := parseStorageDeadline(params.Get(storageRESTDeadline)); ok {
+		return context.WithDeadline(context.Background(), deadline)
+	}
+	return context.Background(), func() {}
+}
+
 // StatVolHandler - stat a volume.
 func (s *storageRESTServer) StatVolHandler(params *grid.MSS) (*VolInfo, *grid.RemoteErr) {
 	if !s.checkID(params.Get(storageRESTDiskID)) {
 		return nil, grid.NewRemoteErr(errDiskNotFound)
 	}
-	info, err := s.getStorage().StatVol(context.Background(), params.Get(storageRESTVolume))
+	ctx, cancel := gridContext(params)
+	defer cancel()
+	if err := ctx.Err(); err != nil {
+		return nil, grid.NewRemoteErr(err)
+	}
+	info, err := s.getStorage().StatVol(ctx, params.Get(storageRESTVolume))
 	if err != nil {
 		return nil, grid.NewRemoteErr(err)
 	}
 	return &info, nil
 }
 
+// VolExistsHandler - existence-only probe for a volume, a single
+// lstat of the volume directory instead of the full StatVol fields.
+// Responds "true" or "false" in plain text.
+func (s *storageRESTServer) VolExistsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	if !isValidVolname(volume) {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	_, err := Lstat(pathJoin(s.endpoint.Path, volume))
+	if err != nil && !osIsNotExist(err) {
+		s.writeErrorResponse(w, osErrToFileErr(err))
+		return
+	}
+	w.Write([]byte(strconv.FormatBool(err == nil)))
+}
+
+// maxAppendInMemSize caps how much of an append body is read into a
+// single up-front allocation; larger bodies are streamed through
+// pooled buffers so one oversized request cannot force a huge
+// allocation. A var so tests can lower it.
+var maxAppendInMemSize = int64(4 << 20)
+
 // AppendFileHandler - append data from the request to the file specified.
+// A missing file is created by default; when the create flag is sent as
+// false the append is rejected with errFileNotFound instead.
 func (s *storageRESTServer) AppendFileHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
 		return
@@ -319,15 +597,77 @@ func (s *storageRESTServer) AppendFileHandler(w http.ResponseWriter, r *http.Req
 	volume := r.Form.Get(storageRESTVolume)
 	filePath := r.Form.Get(storageRESTFilePath)
 
-	buf := make([]byte, r.ContentLength)
-	_, err := io.ReadFull(r.Body, buf)
-	if err != nil {
-		s.writeErrorResponse(w, err)
+	create := true
+	if v := r.Form.Get(storageRESTCreate); v != "" {
+		var err error
+		create, err = strconv.ParseBool(v)
+		if err != nil {
+			s.writeErrorResponse(w, errInvalidArgument)
+			return
+		}
+	}
+	// The create flag is handled inside the storage layer, where the
+	// existence check and the append are a single open, so a
+	// concurrent delete cannot slip in between.
+	appendFn := s.getStorage().AppendFile
+	if !create {
+		appendFn = s.getStorage().AppendFileIfExists
+	}
+
+	if r.ContentLength < 0 {
+		s.writeErrorResponse(w, errInvalidArgument)
 		return
 	}
-	err = s.getStorage().AppendFile(r.Context(), volume, filePath, buf)
-	if err != nil {
-		s.writeErrorResponse(w, err)
+	if r.ContentLength <= maxAppendInMemSize {
+		// Fast path: small bodies land in a single allocation and a
+		// single append, metadata writes stay on this path.
+		buf := make([]byte, r.ContentLength)
+		if _, err := io.ReadFull(r.Body, buf); err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+		err := driveWriteGateFor(s.endpoint.String()).run(r.Context(), "AppendFile", func() error {
+			return appendFn(r.Context(), volume, filePath, buf)
+		})
+		if err != nil {
+			s.writeErrorResponse(w, err)
+		}
+		return
+	}
+
+	// Large bodies are appended chunk by chunk through a pooled
+	// buffer instead of one ContentLength-sized allocation.
+	b := poolBuf128k.Get().([]byte)
+	defer poolBuf128k.Put(b)
+	gate := driveWriteGateFor(s.endpoint.String())
+	var written int64
+	for {
+		n, err := io.ReadFull(r.Body, b)
+		if n > 0 {
+			// Each chunk takes its own turn through the gate so a
+			// slot is never held while waiting on the network.
+			aerr := gate.run(r.Context(), "AppendFile", func() error {
+				return appendFn(r.Context(), volume, filePath, b[:n])
+			})
+			if aerr != nil {
+				s.writeErrorResponse(w, aerr)
+				return
+			}
+			written += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			if written != r.ContentLength {
+				// The body ended short of its declared length;
+				// earlier chunks are already on disk, so surface
+				// the truncation instead of accepting it.
+				s.writeErrorResponse(w, io.ErrUnexpectedEOF)
+			}
+			return
+		}
+		if err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
 	}
 }
 
@@ -348,8 +688,10 @@ func (s *storageRESTServer) CreateFileHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	done, body := keepHTTPReqResponseAlive(w, r)
-	done(s.getStorage().CreateFile(r.Context(), origvolume, volume, filePath, int64(fileSize), body))
+	done, body := keepHTTPReqResponseAlive(w, r, "CreateFile")
+	done(driveWriteGateFor(s.endpoint.String()).run(r.Context(), "CreateFile", func() error {
+		return s.getStorage().CreateFile(r.Context(), origvolume, volume, filePath, int64(fileSize), body)
+	}))
 }
 
 // DeleteVersionHandler delete updated metadata.
@@ -360,6 +702,18 @@ func (s *storageRESTServer) DeleteVersionHandler(p *DeleteVersionHandlerParams)
 	volume := p.Volume
 	filePath := p.FilePath
 	forceDelMarker := p.ForceDelMarker
+	if err := checkStoragePaths(volume, filePath); err != nil {
+		return np, grid.NewRemoteErr(err)
+	}
+
+	if window := softDeleteWindowFor(s.endpoint.String()); window > 0 {
+		// Grid handlers carry no request context; bound the
+		// multi-step trash sequence so a wedged drive cannot hold
+		// it forever.
+		ctx, cancel := context.WithTimeout(GlobalContext, globalDriveConfig.GetMaxTimeout())
+		defer cancel()
+		return np, grid.NewRemoteErr(s.softDeleteVersion(ctx, volume, filePath, p.FI, forceDelMarker, window))
+	}
 
 	opts := DeleteOptions{}
 	err := s.getStorage().DeleteVersion(context.Background(), volume, filePath, p.FI, forceDelMarker, opts)
@@ -375,6 +729,9 @@ func (s *storageRESTServer) ReadVersionHandlerWS(params *grid.MSS) (*FileInfo, *
 	volume := params.Get(storageRESTVolume)
 	filePath := params.Get(storageRESTFilePath)
 	versionID := params.Get(storageRESTVersionID)
+	if err := checkStoragePaths(origvolume, volume, filePath); err != nil {
+		return nil, grid.NewRemoteErr(err)
+	}
 
 	healing, err := strconv.ParseBool(params.Get(storageRESTHealing))
 	if err != nil {
@@ -386,7 +743,12 @@ func (s *storageRESTServer) ReadVersionHandlerWS(params *grid.MSS) (*FileInfo, *
 		return nil, grid.NewRemoteErr(err)
 	}
 
-	fi, err := s.getStorage().ReadVersion(context.Background(), origvolume, volume, filePath, versionID, ReadOptions{
+	ctx, cancel := gridContext(params)
+	defer cancel()
+	if err := ctx.Err(); err != nil {
+		return nil, grid.NewRemoteErr(err)
+	}
+	fi, err := s.getStorage().ReadVersion(ctx, origvolume, volume, filePath, versionID, ReadOptions{
 		InclFreeVersions: inclFreeVersions,
 		ReadData:         false,
 		Healing:          healing,
@@ -394,6 +756,15 @@ func (s *storageRESTServer) ReadVersionHandlerWS(params *grid.MSS) (*FileInfo, *
 	if err != nil {
 		return nil, grid.NewRemoteErr(err)
 	}
+	// Conditional read: when the on-disk version still matches the
+	// caller's signature, skip re-serializing the full FileInfo. The
+	// disk read above still happens, the savings are serialization
+	// and network bytes for the common unchanged case.
+	if sig := params.Get(storageRESTIfModSig); sig != "" {
+		if mt, perr := strconv.ParseInt(sig, 10, 64); perr == nil && fi.ModTime.UnixNano() == mt {
+			return nil, grid.NewRemoteErr(errFileVersionNotModified)
+		}
+	}
 	return &fi, nil
 }
 
@@ -428,9 +799,116 @@ func (s *storageRESTServer) ReadVersionHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Conditional read, see ReadVersionHandlerWS.
+	if sig := r.Form.Get(storageRESTIfModSig); sig != "" {
+		if mt, perr := strconv.ParseInt(sig, 10, 64); perr == nil && fi.ModTime.UnixNano() == mt {
+			s.writeErrorResponse(w, errFileVersionNotModified)
+			return
+		}
+	}
+
 	storageLogIf(r.Context(), msgp.Encode(w, &fi))
 }
 
+// ReadVersionsHandler reads metadata for a batch of versions and
+// streams one response per entry, strictly in input order, so callers
+// correlate responses to requests by position. A failed entry carries
+// its error in the response and does not abort the batch.
+func (s *storageRESTServer) ReadVersionsHandler(ctx context.Context, req *ReadVersionsReq, out chan<- *ReadVersionsResp) *grid.RemoteErr {
+	if !s.checkID(req.DiskID) {
+		return grid.NewRemoteErr(errDiskNotFound)
+	}
+	if len(req.Entries) > readVersionsMaxBatch {
+		return grid.NewRemoteErr(errInvalidArgument)
+	}
+	for i, entry := range req.Entries {
+		var fi FileInfo
+		// Entry paths arrive in the request body, so they get the
+		// same traversal check as form parameters; a bad entry
+		// fails its slot without aborting the batch.
+		err := checkStoragePaths(entry.Volume, entry.FilePath)
+		if err == nil {
+			fi, err = s.getStorage().ReadVersion(ctx, "", entry.Volume, entry.FilePath, entry.VersionID, ReadOptions{
+				InclFreeVersions: entry.InclFreeVersions,
+				ReadData:         entry.ReadData,
+				Healing:          entry.Healing,
+			})
+		}
+		// Responses are pooled, reset every field.
+		resp := storageReadVersionsRPC.NewResponse()
+		resp.Idx = i
+		resp.Err = ""
+		resp.FI = nil
+		if err != nil {
+			resp.Err = err.Error()
+		} else {
+			resp.FI = &fi
+		}
+		select {
+		case <-ctx.Done():
+			return grid.NewRemoteErr(ctx.Err())
+		case out <- resp:
+		}
+	}
+	return nil
+}
+
+// weakETag computes a weak entity tag for a version from its metadata
+// only. It is cheap to compute, but only usable where weak validation
+// semantics are acceptable - it is not a content hash.
+func weakETag(fi *FileInfo) string {
+	h := xxh3.HashString(fmt.Sprintf("%d:%d:%s", fi.Size, fi.ModTime.UnixNano(), fi.VersionID))
+	return fmt.Sprintf(`W/"%016x"`, h)
+}
+
+// ReadWeakETagHandler - returns a weak ETag for a version computed from
+// metadata only, distinct from the strong content ETag.
+func (s *storageRESTServer) ReadWeakETagHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	versionID := r.Form.Get(storageRESTVersionID)
+
+	fi, err := s.getStorage().ReadVersion(r.Context(), "", volume, filePath, versionID, ReadOptions{})
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	w.Write([]byte(weakETag(&fi)))
+}
+
+// ShardLayoutHandler - returns which erasure shard of an object this
+// drive holds and the expected shard distribution from the object's
+// metadata, for verifying shard placement across a set.
+func (s *storageRESTServer) ShardLayoutHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	versionID := r.Form.Get(storageRESTVersionID)
+
+	fi, err := s.getStorage().ReadVersion(r.Context(), "", volume, filePath, versionID, ReadOptions{})
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	layout := ObjectShardLayout{
+		ShardIndex:   fi.Erasure.Index,
+		DataBlocks:   fi.Erasure.DataBlocks,
+		ParityBlocks: fi.Erasure.ParityBlocks,
+		Distribution: fi.Erasure.Distribution,
+		PoolIdx:      s.endpoint.PoolIdx,
+		SetIdx:       s.endpoint.SetIdx,
+		DiskIdx:      s.endpoint.DiskIdx,
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &layout))
+}
+
 // WriteMetadataHandler rpc handler to write new updated metadata.
 func (s *storageRESTServer) WriteMetadataHandler(p *MetadataHandlerParams) (np grid.NoPayload, gerr *grid.RemoteErr) {
 	if !s.checkID(p.DiskID) {
@@ -440,8 +918,13 @@ func (s *storageRESTServer) WriteMetadataHandler(p *MetadataHandlerParams) (np g
 	volume := p.Volume
 	filePath := p.FilePath
 	origvolume := p.OrigVolume
+	if err := checkStoragePaths(origvolume, volume, filePath); err != nil {
+		return grid.NewNPErr(err)
+	}
 
-	err := s.getStorage().WriteMetadata(context.Background(), origvolume, volume, filePath, p.FI)
+	err := driveWriteGateFor(s.endpoint.String()).run(context.Background(), "WriteMetadata", func() error {
+		return s.getStorage().WriteMetadata(context.Background(), origvolume, volume, filePath, p.FI)
+	})
 	return np, grid.NewRemoteErr(err)
 }
 
@@ -452,6 +935,9 @@ func (s *storageRESTServer) UpdateMetadataHandler(p *MetadataHandlerParams) (gri
 	}
 	volume := p.Volume
 	filePath := p.FilePath
+	if err := checkStoragePaths(volume, filePath); err != nil {
+		return grid.NewNPErr(err)
+	}
 
 	return grid.NewNPErr(s.getStorage().UpdateMetadata(context.Background(), volume, filePath, p.FI, p.UpdateOpts))
 }
@@ -463,11 +949,45 @@ func (s *storageRESTServer) CheckPartsHandler(p *CheckPartsHandlerParams) (*Chec
 	}
 	volume := p.Volume
 	filePath := p.FilePath
+	if err := checkStoragePaths(volume, filePath); err != nil {
+		return nil, grid.NewRemoteErr(err)
+	}
 
 	resp, err := s.getStorage().CheckParts(context.Background(), volume, filePath, p.FI)
 	return resp, grid.NewRemoteErr(err)
 }
 
+// StatPartsHandler stats many parts of one object in a single call.
+// Each requested part number gets its own result slot, so one missing
+// part does not fail the batch.
+func (s *storageRESTServer) StatPartsHandler(p *StatPartsReq) (*StatPartsResp, *grid.RemoteErr) {
+	if !s.checkID(p.DiskID) {
+		return nil, grid.NewRemoteErr(errDiskNotFound)
+	}
+	if err := checkStoragePaths(p.Volume, p.Path); err != nil {
+		return nil, grid.NewRemoteErr(err)
+	}
+	resp := &StatPartsResp{Results: make([]StatPartResult, len(p.Parts))}
+	for i, num := range p.Parts {
+		res := &resp.Results[i]
+		res.Number = num
+		partPath := pathJoin(p.Path, fmt.Sprintf("part.%d", num))
+		st, err := s.getStorage().StatInfoFile(context.Background(), p.Volume, partPath, false)
+		if err != nil {
+			res.Err = err.Error()
+			continue
+		}
+		if len(st) == 0 {
+			res.Err = errFileNotFound.Error()
+			continue
+		}
+		res.Exists = true
+		res.Size = st[0].Size
+		res.ModTime = st[0].ModTime
+	}
+	return resp, nil
+}
+
 func (s *storageRESTServer) WriteAllHandler(p *WriteAllHandlerParams) (grid.NoPayload, *grid.RemoteErr) {
 	if !s.checkID(p.DiskID) {
 		return grid.NewNPErr(errDiskNotFound)
@@ -475,8 +995,13 @@ func (s *storageRESTServer) WriteAllHandler(p *WriteAllHandlerParams) (grid.NoPa
 
 	volume := p.Volume
 	filePath := p.FilePath
+	if err := checkStoragePaths(volume, filePath); err != nil {
+		return grid.NewNPErr(err)
+	}
 
-	return grid.NewNPErr(s.getStorage().WriteAll(context.Background(), volume, filePath, p.Buf))
+	return grid.NewNPErr(driveWriteGateFor(s.endpoint.String()).run(context.Background(), "WriteAll", func() error {
+		return s.getStorage().WriteAll(context.Background(), volume, filePath, p.Buf)
+	}))
 }
 
 // ReadAllHandler - read all the contents of a file.
@@ -487,11 +1012,110 @@ func (s *storageRESTServer) ReadAllHandler(p *ReadAllHandlerParams) (*grid.Bytes
 
 	volume := p.Volume
 	filePath := p.FilePath
+	if err := checkStoragePaths(volume, filePath); err != nil {
+		return nil, grid.NewRemoteErr(err)
+	}
 
 	buf, err := s.getStorage().ReadAll(context.Background(), volume, filePath)
 	return grid.NewBytesWith(buf), grid.NewRemoteErr(err)
 }
 
+// ReadAllHTTPHandler - serves a file's full content over HTTP with an
+// optional server-side encoding. With encoding "base64" or "hex" the
+// content is returned as text/plain so small files can be inspected
+// through text-only channels; the default stays raw bytes.
+func (s *storageRESTServer) ReadAllHTTPHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+
+	buf, err := s.getStorage().ReadAll(r.Context(), volume, filePath)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	var out []byte
+	contentType := "application/octet-stream"
+	if r.Form.Get(storageRESTSniff) == "true" {
+		contentType = sniffContentType(buf)
+	}
+	switch r.Form.Get(storageRESTEncoding) {
+	case "":
+		out = buf
+	case "base64":
+		out = make([]byte, base64.StdEncoding.EncodedLen(len(buf)))
+		base64.StdEncoding.Encode(out, buf)
+		contentType = "text/plain"
+	case "hex":
+		out = make([]byte, hex.EncodedLen(len(buf)))
+		hex.Encode(out, buf)
+		contentType = "text/plain"
+	default:
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	if len(out) >= internodeCompressMinSize && strings.Contains(r.Header.Get(xhttp.MinIOAcceptEncoding), internodeCodecS2) {
+		out = s2.Encode(nil, out)
+		w.Header().Set(xhttp.ContentEncoding, internodeCodecS2)
+	}
+	w.Header().Set(xhttp.ContentType, contentType)
+	w.Header().Set(xhttp.ContentLength, strconv.Itoa(len(out)))
+	w.Write(out)
+}
+
+// internodeCodecS2 is the only payload codec negotiated between peers
+// for full-content transfers; payloads below internodeCompressMinSize
+// are never compressed since the framing overhead outweighs the gain.
+const (
+	internodeCodecS2         = "s2"
+	internodeCompressMinSize = 4 << 10
+)
+
+// WriteAllHTTPHandler - writes a file's full content over HTTP. The
+// payload may be compressed with a negotiated codec, indicated through
+// the Content-Encoding header; unknown codecs are rejected.
+func (s *storageRESTServer) WriteAllHTTPHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+
+	if r.ContentLength < 0 {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	// Read exactly the declared length; a single right-sized
+	// allocation, and truncated bodies fail here instead of being
+	// written out short.
+	buf := make([]byte, r.ContentLength)
+	if _, err := io.ReadFull(r.Body, buf); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	var err error
+	switch r.Header.Get(xhttp.ContentEncoding) {
+	case "":
+	case internodeCodecS2:
+		buf, err = s2.Decode(nil, buf)
+		if err != nil {
+			s.writeErrorResponse(w, errInvalidArgument)
+			return
+		}
+	default:
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	err = driveWriteGateFor(s.endpoint.String()).run(r.Context(), "WriteAll", func() error {
+		return s.getStorage().WriteAll(r.Context(), volume, filePath, buf)
+	})
+	if err != nil {
+		s.writeErrorResponse(w, err)
+	}
+}
+
 // ReadXLHandler - read xl.meta for an object at path.
 func (s *storageRESTServer) ReadXLHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
@@ -518,7 +1142,15 @@ func (s *storageRESTServer) ReadXLHandlerWS(params *grid.MSS) (*RawFileInfo, *gr
 
 	volume := params.Get(storageRESTVolume)
 	filePath := params.Get(storageRESTFilePath)
-	rf, err := s.getStorage().ReadXL(context.Background(), volume, filePath, false)
+	if err := checkStoragePaths(volume, filePath); err != nil {
+		return nil, grid.NewRemoteErr(err)
+	}
+	ctx, cancel := gridContext(params)
+	defer cancel()
+	if err := ctx.Err(); err != nil {
+		return nil, grid.NewRemoteErr(err)
+	}
+	rf, err := s.getStorage().ReadXL(ctx, volume, filePath, false)
 	if err != nil {
 		return nil, grid.NewRemoteErr(err)
 	}
@@ -539,7 +1171,7 @@ func (s *storageRESTServer) ReadPartsHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	done := keepHTTPResponseAlive(w)
+	done := keepHTTPResponseAlive(w, "ReadParts")
 	infos, err := s.getStorage().ReadParts(r.Context(), volume, preq.Paths...)
 	done(nil)
 	if err != nil {
@@ -572,6 +1204,24 @@ func (s *storageRESTServer) ReadFileHandler(w http.ResponseWriter, r *http.Reque
 		s.writeErrorResponse(w, errInvalidArgument)
 		return
 	}
+	if v := r.Form.Get(storageRESTShardSize); v != "" {
+		// Verified-read mode: the file is streaming-bitrot
+		// formatted, verify each block's checksum while reading.
+		shardSize, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+		algo := BitrotAlgorithmFromString(r.Form.Get(storageRESTBitrotAlgo))
+		buf, err := verifiedReadFile(r.Context(), s.getStorage(), volume, filePath, int64(offset), int64(length), algo, shardSize)
+		if err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+		w.Header().Set(xhttp.ContentLength, strconv.Itoa(len(buf)))
+		w.Write(buf)
+		return
+	}
 	var verifier *BitrotVerifier
 	if r.Form.Get(storageRESTBitrotAlgo) != "" {
 		hashStr := r.Form.Get(storageRESTBitrotHash)
@@ -585,16 +1235,107 @@ func (s *storageRESTServer) ReadFileHandler(w http.ResponseWriter, r *http.Reque
 	}
 	buf := make([]byte, length)
 	defer metaDataPoolPut(buf) // Reuse if we can.
-	_, err = s.getStorage().ReadFile(r.Context(), volume, filePath, int64(offset), buf, verifier)
+	n, err := s.getStorage().ReadFile(r.Context(), volume, filePath, int64(offset), buf, verifier)
 	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF || err == errLessData {
+			// The shard holds fewer bytes than requested: local
+			// damage, report it distinctly so the coordinator
+			// reconstructs from parity instead of using partial data.
+			err = errShortShard
+		}
 		s.writeErrorResponse(w, err)
 		return
 	}
+	if n < int64(length) {
+		s.writeErrorResponse(w, errShortShard)
+		return
+	}
+	if r.Form.Get(storageRESTSniff) == "true" {
+		w.Header().Set(xhttp.ContentType, sniffContentType(buf))
+	}
 	w.Header().Set(xhttp.ContentLength, strconv.Itoa(len(buf)))
 	w.Write(buf)
 }
 
+// sniffContentType derives a content-type from the leading bytes of
+// data. http.DetectContentType classifies JSON as plain text, so
+// documents whose first significant byte opens an object or array are
+// special-cased.
+func sniffContentType(data []byte) string {
+	if len(data) > 512 {
+		data = data[:512]
+	}
+	if contentType := http.DetectContentType(data); !strings.HasPrefix(contentType, "text/plain") {
+		return contentType
+	}
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return "application/json"
+	}
+	return "text/plain; charset=utf-8"
+}
+
 // ReadFileStreamHandler - read section of a file.
+// verifiedReadFile reads a streaming-bitrot formatted file, verifying
+// every block's checksum before its payload is returned. Offset and
+// length apply to the decoded payload; the first block failing its
+// checksum aborts the read with errCorruptedData so bytes from a bad
+// block are never served.
+func verifiedReadFile(ctx context.Context, store StorageAPI, volume, path string, offset, length int64, algo BitrotAlgorithm, shardSize int64) ([]byte, error) {
+	if !algo.Available() || shardSize <= 0 || offset < 0 || length < 0 {
+		return nil, errInvalidArgument
+	}
+	rc, err := store.ReadFileStream(ctx, volume, path, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	h := algo.New()
+	hashBuf := make([]byte, h.Size())
+	block := make([]byte, shardSize)
+	out := make([]byte, 0, length)
+	skip := offset
+	for int64(len(out)) < length {
+		if _, err := io.ReadFull(rc, hashBuf); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errFileCorrupt
+		}
+		n, err := io.ReadFull(rc, block)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return nil, errFileCorrupt
+		}
+		h.Reset()
+		h.Write(block[:n])
+		if !bytes.Equal(h.Sum(nil), hashBuf) {
+			return nil, errCorruptedData
+		}
+		payload := block[:n]
+		if skip > 0 {
+			if skip >= int64(len(payload)) {
+				skip -= int64(len(payload))
+				continue
+			}
+			payload = payload[skip:]
+			skip = 0
+		}
+		if need := length - int64(len(out)); int64(len(payload)) > need {
+			payload = payload[:need]
+		}
+		out = append(out, payload...)
+		if n < len(block) {
+			// Short block terminates the file.
+			break
+		}
+	}
+	if int64(len(out)) < length {
+		return nil, errLessData
+	}
+	return out, nil
+}
+
 func (s *storageRESTServer) ReadFileStreamHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
 		return
@@ -612,131 +1353,2039 @@ func (s *storageRESTServer) ReadFileStreamHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Proxies and CDNs issue standard Range requests against this
+	// endpoint; narrow the requested window accordingly and answer
+	// with 206 and exact Content-Range/Content-Length. Without a
+	// Range header behavior is unchanged.
+	if rangeHdr := r.Header.Get(xhttp.Range); rangeHdr != "" {
+		total := length
+		if total < 0 {
+			st, serr := Lstat(pathJoin(s.endpoint.Path, volume, filePath))
+			if serr != nil {
+				s.writeErrorResponse(w, osErrToFileErr(serr))
+				return
+			}
+			total = st.Size() - offset
+		}
+		rs, rerr := parseRequestRangeSpec(rangeHdr)
+		if rerr != nil {
+			w.Header().Set(xhttp.ContentRange, fmt.Sprintf("bytes */%d", total))
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		start, rangeLength, rerr := rs.GetOffsetLength(total)
+		if rerr != nil {
+			w.Header().Set(xhttp.ContentRange, fmt.Sprintf("bytes */%d", total))
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		rc, err := s.getStorage().ReadFileStream(r.Context(), volume, filePath, offset+start, rangeLength)
+		if err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+		defer rc.Close()
+
+		w.Header().Set(xhttp.ContentRange, fmt.Sprintf("bytes %d-%d/%d", start, start+rangeLength-1, total))
+		w.Header().Set(xhttp.ContentLength, strconv.FormatInt(rangeLength, 10))
+		w.WriteHeader(http.StatusPartialContent)
+		// No trailing status marker on ranged responses, the exact
+		// Content-Length already delimits the payload.
+		_, err = xioutil.CopyAdaptive(w, rc)
+		if !xnet.IsNetworkOrHostDown(err, true) {
+			storageLogIf(r.Context(), err)
+		}
+		return
+	}
+
+	// When the caller requests an integrity trailer, hash the streamed
+	// bytes with the named bitrot algorithm and emit the digest as an
+	// HTTP trailer once the body is done. Hashing reads through a tee,
+	// which rules out any zero-copy transfer below, so this is strictly
+	// opt-in. Ranged responses above carry an exact Content-Length and
+	// cannot carry trailers, they stay unaffected.
+	var hasher hash.Hash
+	if algoName := r.Header.Get(xhttp.MinIOReadHashAlgo); algoName != "" {
+		algo := BitrotAlgorithmFromString(algoName)
+		if !algo.Available() {
+			s.writeErrorResponse(w, errBitrotHashAlgoInvalid)
+			return
+		}
+		hasher = algo.New()
+		w.Header().Set("Trailer", xhttp.MinIOReadHashSum)
+	}
+
 	rc, err := s.getStorage().ReadFileStream(r.Context(), volume, filePath, offset, length)
 	if err != nil {
 		s.writeErrorResponse(w, err)
 		return
 	}
-	defer rc.Close()
+	defer rc.Close()
+
+	var src io.Reader = rc
+	if hasher != nil {
+		src = io.TeeReader(rc, hasher)
+	}
+	_, err = xioutil.CopyAdaptive(w, src)
+	if !xnet.IsNetworkOrHostDown(err, true) { // do not need to log disconnected clients
+		storageLogIf(r.Context(), err)
+	}
+	if hasher != nil && err == nil {
+		w.Header().Set(xhttp.MinIOReadHashSum, hex.EncodeToString(hasher.Sum(nil)))
+	}
+	if length >= 0 {
+		// Trailing status marker so the client can tell a complete
+		// stream from one truncated by a mid-read error, the response
+		// status is already on the wire at this point.
+		marker := [1]byte{0}
+		if err != nil {
+			marker[0] = 1
+		}
+		w.Write(marker[:])
+	}
+}
+
+// readTransforms is the whitelist of server-side stream transforms a
+// caller may declare in a read pipeline. Each stage wraps the output
+// of the previous one.
+var readTransforms = map[string]func(io.Reader) io.Reader{
+	"decompress:s2": func(r io.Reader) io.Reader { return s2.NewReader(r) },
+}
+
+// ReadTransformedHandler - streams a file through a declared
+// server-side transform pipeline, e.g. decompressing on the drive's
+// node so the caller reads original bytes. Unknown transforms are
+// rejected outright.
+func (s *storageRESTServer) ReadTransformedHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+
+	var stages []func(io.Reader) io.Reader
+	for _, name := range strings.Split(r.Form.Get(storageRESTPipeline), ",") {
+		if name == "" {
+			continue
+		}
+		wrap, ok := readTransforms[name]
+		if !ok {
+			s.writeErrorResponse(w, errInvalidArgument)
+			return
+		}
+		stages = append(stages, wrap)
+	}
+
+	st, err := os.Lstat(pathJoin(s.endpoint.Path, volume, filePath))
+	if err != nil {
+		s.writeErrorResponse(w, osErrToFileErr(err))
+		return
+	}
+	rc, err := s.getStorage().ReadFileStream(r.Context(), volume, filePath, 0, st.Size())
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	defer rc.Close()
+
+	rd := io.Reader(rc)
+	for _, wrap := range stages {
+		rd = wrap(rd)
+	}
+	_, err = xioutil.Copy(w, rd)
+	if !xnet.IsNetworkOrHostDown(err, true) { // do not need to log disconnected clients
+		storageLogIf(r.Context(), err)
+	}
+}
+
+// Maximum buffer a read session may prefetch and how long an idle
+// session is kept around before it auto-expires.
+const (
+	readSessionMaxBuffer = 8 << 20
+	readSessionTTL       = 30 * time.Second
+)
+
+// readSession holds a prefetched window of a file so subsequent small
+// reads within the declared range are served from memory instead of
+// separate disk reads.
+type readSession struct {
+	volume  string
+	path    string
+	offset  int64
+	buf     []byte
+	expires time.Time
+}
+
+var globalReadSessions = struct {
+	sync.Mutex
+	m map[string]*readSession
+}{m: make(map[string]*readSession)}
+
+func putReadSession(id string, sess *readSession) {
+	globalReadSessions.Lock()
+	defer globalReadSessions.Unlock()
+	sess.expires = time.Now().Add(readSessionTTL)
+	globalReadSessions.m[id] = sess
+
+	// Reap the session once it can no longer be refreshed, so an
+	// abandoned session does not pin its buffer forever.
+	time.AfterFunc(readSessionTTL, func() { reapReadSession(id) })
+}
+
+func reapReadSession(id string) {
+	globalReadSessions.Lock()
+	defer globalReadSessions.Unlock()
+	sess, ok := globalReadSessions.m[id]
+	if !ok {
+		return
+	}
+	if time.Now().Before(sess.expires) {
+		// Still in use, check again when the refreshed TTL lapses.
+		time.AfterFunc(time.Until(sess.expires), func() { reapReadSession(id) })
+		return
+	}
+	delete(globalReadSessions.m, id)
+}
+
+// getReadSession returns the session for id if it has not expired and
+// refreshes its TTL.
+func getReadSession(id string) *readSession {
+	globalReadSessions.Lock()
+	defer globalReadSessions.Unlock()
+	sess, ok := globalReadSessions.m[id]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(sess.expires) {
+		delete(globalReadSessions.m, id)
+		return nil
+	}
+	sess.expires = time.Now().Add(readSessionTTL)
+	return sess
+}
+
+// OpenReadSessionHandler - declares an overall range the caller intends
+// to read sequentially. The server prefetches the range into a bounded
+// buffer so subsequent small reads are served from memory, and returns
+// the session id.
+func (s *storageRESTServer) OpenReadSessionHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	offset, err := strconv.ParseInt(r.Form.Get(storageRESTOffset), 10, 64)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	length, err := strconv.ParseInt(r.Form.Get(storageRESTLength), 10, 64)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if offset < 0 || length <= 0 {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	if length > readSessionMaxBuffer {
+		length = readSessionMaxBuffer
+	}
+
+	buf := make([]byte, length)
+	if _, err = s.getStorage().ReadFile(r.Context(), volume, filePath, offset, buf, nil); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	id := mustGetUUID()
+	putReadSession(id, &readSession{volume: volume, path: filePath, offset: offset, buf: buf})
+	w.Write([]byte(id))
+}
+
+// ReadSessionHandler - serves a read from a previously opened read
+// session, falling back to a direct disk read when the requested range
+// is outside the prefetched window.
+func (s *storageRESTServer) ReadSessionHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	offset, err := strconv.ParseInt(r.Form.Get(storageRESTOffset), 10, 64)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	length, err := strconv.ParseInt(r.Form.Get(storageRESTLength), 10, 64)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if offset < 0 || length < 0 || length > readSessionMaxBuffer {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+
+	sess := getReadSession(r.Form.Get(storageRESTSessionID))
+	if sess == nil {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+
+	if offset >= sess.offset && offset+length <= sess.offset+int64(len(sess.buf)) {
+		w.Header().Set(xhttp.ContentLength, strconv.FormatInt(length, 10))
+		w.Write(sess.buf[offset-sess.offset : offset-sess.offset+length])
+		return
+	}
+
+	// Outside the prefetched window, read from disk. The
+	// Content-Length is committed only once the read succeeds so a
+	// failure still produces a well-formed error response.
+	buf := make([]byte, length)
+	if _, err = s.getStorage().ReadFile(r.Context(), sess.volume, sess.path, offset, buf, nil); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	w.Header().Set(xhttp.ContentLength, strconv.FormatInt(length, 10))
+	w.Write(buf)
+}
+
+// CloseReadSessionHandler - releases a read session and its buffer.
+func (s *storageRESTServer) CloseReadSessionHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	globalReadSessions.Lock()
+	delete(globalReadSessions.m, r.Form.Get(storageRESTSessionID))
+	globalReadSessions.Unlock()
+}
+
+// ListDirHandler - list a directory.
+func (s *storageRESTServer) ListDirHandler(ctx context.Context, params *grid.MSS, out chan<- *ListDirResult) *grid.RemoteErr {
+	if !s.checkID(params.Get(storageRESTDiskID)) {
+		return grid.NewRemoteErr(errDiskNotFound)
+	}
+	volume := params.Get(storageRESTVolume)
+	dirPath := params.Get(storageRESTDirPath)
+	origvolume := params.Get(storageRESTOrigVolume)
+	count, err := strconv.Atoi(params.Get(storageRESTCount))
+	if err != nil {
+		return grid.NewRemoteErr(err)
+	}
+
+	entries, err := s.getStorage().ListDir(ctx, origvolume, volume, dirPath, count)
+	if err != nil {
+		return grid.NewRemoteErr(err)
+	}
+	out <- &ListDirResult{Entries: entries}
+	return nil
+}
+
+// listDirChunk is how many entries ListDirChunkedHandler packs into
+// each stream message, so very large directories are never encoded as
+// one giant message.
+const listDirChunk = 1000
+
+// ListDirChunkedHandler - list a directory, streaming the entries in
+// fixed-size chunks so the client can start processing before the
+// listing is fully materialized on the wire. Kept as a separate route
+// since ListDirHandler clients expect the entries in a single message.
+func (s *storageRESTServer) ListDirChunkedHandler(ctx context.Context, params *grid.MSS, out chan<- *ListDirResult) *grid.RemoteErr {
+	if !s.checkID(params.Get(storageRESTDiskID)) {
+		return grid.NewRemoteErr(errDiskNotFound)
+	}
+	volume := params.Get(storageRESTVolume)
+	dirPath := params.Get(storageRESTDirPath)
+	origvolume := params.Get(storageRESTOrigVolume)
+	count, err := strconv.Atoi(params.Get(storageRESTCount))
+	if err != nil {
+		return grid.NewRemoteErr(err)
+	}
+
+	entries, err := s.getStorage().ListDir(ctx, origvolume, volume, dirPath, count)
+	if err != nil {
+		return grid.NewRemoteErr(err)
+	}
+	for len(entries) > 0 {
+		n := min(listDirChunk, len(entries))
+		out <- &ListDirResult{Entries: entries[:n]}
+		entries = entries[n:]
+	}
+	return nil
+}
+
+// listDirBudgetBatch is how many entries a budgeted listing packs per
+// stream message; the budget is checked between batches.
+const listDirBudgetBatch = 100
+
+// streamListDirBudget sends sorted entries in batches until either all
+// are sent or now passes deadline, in which case a final message with
+// Truncated set and the last sent entry as continuation token ends the
+// stream. Split out with an injectable clock so the truncation logic
+// is testable without a slow drive.
+func streamListDirBudget(entries []string, deadline time.Time, now func() time.Time, send func(*ListDirBudgetResult)) {
+	var last string
+	for len(entries) > 0 {
+		if now().After(deadline) {
+			send(&ListDirBudgetResult{Truncated: true, Token: last})
+			return
+		}
+		n := min(listDirBudgetBatch, len(entries))
+		send(&ListDirBudgetResult{Entries: entries[:n]})
+		last = entries[n-1]
+		entries = entries[n:]
+	}
+}
+
+// ListDirBudgetHandler - list a directory within a time budget,
+// returning the entries gathered so far plus a continuation token when
+// the budget expires before the listing completes.
+func (s *storageRESTServer) ListDirBudgetHandler(ctx context.Context, params *grid.MSS, out chan<- *ListDirBudgetResult) *grid.RemoteErr {
+	if !s.checkID(params.Get(storageRESTDiskID)) {
+		return grid.NewRemoteErr(errDiskNotFound)
+	}
+	volume := params.Get(storageRESTVolume)
+	dirPath := params.Get(storageRESTDirPath)
+	origvolume := params.Get(storageRESTOrigVolume)
+	count, err := strconv.Atoi(params.Get(storageRESTCount))
+	if err != nil {
+		return grid.NewRemoteErr(err)
+	}
+	budget, err := time.ParseDuration(params.Get(storageRESTBudget))
+	if err != nil {
+		return grid.NewRemoteErr(err)
+	}
+	deadline := time.Now().Add(budget)
+
+	entries, err := s.getStorage().ListDir(ctx, origvolume, volume, dirPath, count)
+	if err != nil {
+		return grid.NewRemoteErr(err)
+	}
+	sort.Strings(entries)
+	if token := params.Get(storageRESTToken); token != "" {
+		for len(entries) > 0 && entries[0] <= token {
+			entries = entries[1:]
+		}
+	}
+	streamListDirBudget(entries, deadline, time.Now, func(res *ListDirBudgetResult) {
+		out <- res
+	})
+	return nil
+}
+
+// DeleteFileHandler - delete a file.
+func (s *storageRESTServer) DeleteFileHandler(p *DeleteFileHandlerParams) (grid.NoPayload, *grid.RemoteErr) {
+	if !s.checkID(p.DiskID) {
+		return grid.NewNPErr(errDiskNotFound)
+	}
+	if err := checkStoragePaths(p.Volume, p.FilePath); err != nil {
+		return grid.NewNPErr(err)
+	}
+	return grid.NewNPErr(s.getStorage().Delete(context.Background(), p.Volume, p.FilePath, p.Opts))
+}
+
+// deleteVersionsWindowSize is the number of FileInfoVersions held in
+// memory at a time; larger batches are spilled to a temp file and
+// re-read window by window so memory stays flat regardless of batch
+// size.
+const deleteVersionsWindowSize = 1000
+
+// maxMsgpPayloadSize caps how many bytes of client-supplied msgp a
+// handler buffers and decodes for one request.
+const maxMsgpPayloadSize = 100 << 20
+
+// readMsgpPayload buffers a client-supplied msgp payload of objects
+// back-to-back msgp objects with defensive limits. The payload is
+// capped at maxMsgpPayloadSize, and a structural pre-pass with
+// msgp.Skip rejects container or string headers claiming more
+// elements than the bytes actually present, so the real decode cannot
+// allocate more than what the client sent.
+func readMsgpPayload(body io.Reader, objects int) ([]byte, error) {
+	buf, err := io.ReadAll(io.LimitReader(body, maxMsgpPayloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(buf)) > maxMsgpPayloadSize {
+		return nil, errInvalidArgument
+	}
+	rem := buf
+	for i := 0; i < objects; i++ {
+		if rem, err = msgp.Skip(rem); err != nil {
+			return nil, errInvalidArgument
+		}
+	}
+	if len(rem) != 0 {
+		return nil, errInvalidArgument
+	}
+	return buf, nil
+}
+
+// DeleteVersionsHandler - delete a set of a versions.
+func (s *storageRESTServer) DeleteVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+
+	volume := r.Form.Get(storageRESTVolume)
+	totalVersions, err := strconv.Atoi(r.Form.Get(storageRESTTotalVersions))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if totalVersions < 0 {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+
+	if totalVersions > deleteVersionsWindowSize {
+		s.deleteVersionsSpilled(w, r, volume, totalVersions)
+		return
+	}
+
+	payload, err := readMsgpPayload(r.Body, totalVersions)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	versions := make([]FileInfoVersions, totalVersions)
+	for i := 0; i < totalVersions; i++ {
+		if payload, err = versions[i].UnmarshalMsg(payload); err != nil {
+			s.writeErrorResponse(w, errInvalidArgument)
+			return
+		}
+	}
+
+	// Report every entry's result as soon as it completes, so the
+	// caller sees progress and a mid-operation disconnect does not
+	// waste the deletes already done. Each entry is one object's
+	// metadata update, so per-entry calls cost the same as a batch.
+	rw := streamHTTPResponse(w, "DeleteVersions")
+	mw := msgp.NewWriter(rw)
+	opts := DeleteOptions{}
+	resps := make(chan DeleteVersionsStreamResult, 1)
+	go func() {
+		defer xioutil.SafeClose(resps)
+		for i := range versions {
+			errs := s.getStorage().DeleteVersions(r.Context(), volume, versions[i:i+1], opts)
+			res := DeleteVersionsStreamResult{Idx: i}
+			if errs[0] != nil {
+				res.Err = errs[0].Error()
+			}
+			resps <- res
+		}
+	}()
+	for res := range resps {
+		if err := res.EncodeMsg(mw); err != nil {
+			rw.CloseWithError(err)
+			return
+		}
+		if err := mw.Flush(); err != nil {
+			rw.CloseWithError(err)
+			return
+		}
+	}
+	rw.CloseWithError(nil)
+}
+
+// deleteVersionsSpilled handles batches too large to materialize in
+// memory. The undecoded request stream is spilled to a temp file first
+// and then processed in bounded windows, so only one window of
+// FileInfoVersions is alive at any time. Results are streamed back a
+// window at a time in the same incremental format as the in-memory
+// path.
+func (s *storageRESTServer) deleteVersionsSpilled(w http.ResponseWriter, r *http.Request, volume string, totalVersions int) {
+	tmp, err := os.CreateTemp("", "delete-versions-spill")
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+	if _, err = xioutil.Copy(tmp, r.Body); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	// Structural pre-pass: skip through every object without decoding
+	// it, so container headers claiming more elements than the spilled
+	// bytes actually hold are rejected before the windowed decode
+	// allocates for them.
+	vr := msgpNewReader(bufio.NewReader(tmp))
+	for i := 0; i < totalVersions; i++ {
+		if err := vr.Skip(); err != nil {
+			readMsgpReaderPoolPut(vr)
+			s.writeErrorResponse(w, errInvalidArgument)
+			return
+		}
+	}
+	readMsgpReaderPoolPut(vr)
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	rw := streamHTTPResponse(w, "DeleteVersions")
+	mw := msgp.NewWriter(rw)
+	decoder := msgpNewReader(bufio.NewReader(tmp))
+	defer readMsgpReaderPoolPut(decoder)
+
+	versions := make([]FileInfoVersions, deleteVersionsWindowSize)
+	opts := DeleteOptions{}
+	idx := 0
+	for remaining := totalVersions; remaining > 0; {
+		window := min(remaining, deleteVersionsWindowSize)
+		for i := 0; i < window; i++ {
+			versions[i] = FileInfoVersions{}
+			if err := versions[i].DecodeMsg(decoder); err != nil {
+				rw.CloseWithError(err)
+				return
+			}
+		}
+		errs := s.getStorage().DeleteVersions(r.Context(), volume, versions[:window], opts)
+		for _, err := range errs {
+			res := DeleteVersionsStreamResult{Idx: idx}
+			if err != nil {
+				res.Err = err.Error()
+			}
+			if err := res.EncodeMsg(mw); err != nil {
+				rw.CloseWithError(err)
+				return
+			}
+			idx++
+		}
+		if err := mw.Flush(); err != nil {
+			rw.CloseWithError(err)
+			return
+		}
+		remaining -= window
+	}
+	rw.CloseWithError(nil)
+}
+
+// RenameDataHandler - renames a meta object and data dir to destination.
+func (s *storageRESTServer) RenameDataHandler(p *RenameDataHandlerParams) (*RenameDataResp, *grid.RemoteErr) {
+	if !s.checkID(p.DiskID) {
+		return nil, grid.NewRemoteErr(errDiskNotFound)
+	}
+
+	if err := checkStoragePaths(p.SrcVolume, p.SrcPath, p.DstVolume, p.DstPath); err != nil {
+		return nil, grid.NewRemoteErr(err)
+	}
+
+	resp, err := s.getStorage().RenameData(context.Background(), p.SrcVolume, p.SrcPath, p.FI, p.DstVolume, p.DstPath, p.Opts)
+	return &resp, grid.NewRemoteErr(err)
+}
+
+// RenameDataInlineHandler - renames a meta object and data dir to destination.
+func (s *storageRESTServer) RenameDataInlineHandler(p *RenameDataInlineHandlerParams) (*RenameDataResp, *grid.RemoteErr) {
+	defer p.Recycle()
+	return s.RenameDataHandler(&p.RenameDataHandlerParams)
+}
+
+// RenameFileHandler - rename a file from source to destination
+func (s *storageRESTServer) RenameFileHandler(p *RenameFileHandlerParams) (grid.NoPayload, *grid.RemoteErr) {
+	if !s.checkID(p.DiskID) {
+		return grid.NewNPErr(errDiskNotFound)
+	}
+	if err := checkStoragePaths(p.SrcVolume, p.SrcFilePath, p.DstVolume, p.DstFilePath); err != nil {
+		return grid.NewNPErr(err)
+	}
+	return grid.NewNPErr(s.getStorage().RenameFile(context.Background(), p.SrcVolume, p.SrcFilePath, p.DstVolume, p.DstFilePath))
+}
+
+// RenamePartHandler - rename a multipart part from source to destination
+func (s *storageRESTServer) RenamePartHandler(p *RenamePartHandlerParams) (grid.NoPayload, *grid.RemoteErr) {
+	if !s.checkID(p.DiskID) {
+		return grid.NewNPErr(errDiskNotFound)
+	}
+	if err := checkStoragePaths(p.SrcVolume, p.SrcFilePath, p.DstVolume, p.DstFilePath); err != nil {
+		return grid.NewNPErr(err)
+	}
+	return grid.NewNPErr(s.getStorage().RenamePart(context.Background(), p.SrcVolume, p.SrcFilePath, p.DstVolume, p.DstFilePath, p.Meta))
+}
+
+// scanOrphanedData walks dirPath inside volume and calls fn for every
+// data directory that is not referenced by the object's xl.meta.
+func (s *storageRESTServer) scanOrphanedData(ctx context.Context, volume, dirPath string, fn func(OrphanedDataInfo) error) error {
+	if contextCanceled(ctx) {
+		return ctx.Err()
+	}
+	store := s.getStorage()
+	entries, err := store.ListDir(ctx, "", volume, dirPath, -1)
+	if err != nil {
+		return err
+	}
+	var hasMeta bool
+	for _, entry := range entries {
+		if entry == xlStorageFormatFile {
+			hasMeta = true
+			break
+		}
+	}
+	if !hasMeta {
+		// Not an object directory, recurse into sub-directories.
+		for _, entry := range entries {
+			if !strings.HasSuffix(entry, SlashSeparator) {
+				continue
+			}
+			if err := s.scanOrphanedData(ctx, volume, pathJoin(dirPath, entry), fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	buf, err := store.ReadAll(ctx, volume, pathJoin(dirPath, xlStorageFormatFile))
+	if err != nil {
+		return err
+	}
+	if !isXL2V1Format(buf) {
+		return nil
+	}
+	var xl xlMetaV2
+	if err = xl.LoadOrConvert(buf); err != nil {
+		return err
+	}
+	wantDirs, err := xl.getDataDirs()
+	if err != nil {
+		return err
+	}
+	want := make(map[string]struct{}, len(wantDirs))
+	for _, dir := range wantDirs {
+		want[dir] = struct{}{}
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry, SlashSeparator) {
+			continue
+		}
+		base := strings.TrimSuffix(entry, SlashSeparator)
+		// Data directories are always UUID named.
+		if _, err := uuid.Parse(base); err != nil {
+			continue
+		}
+		if _, ok := want[base]; ok {
+			continue
+		}
+		orphan := OrphanedDataInfo{Path: pathJoin(dirPath, base)}
+		if stats, err := store.StatInfoFile(ctx, volume, orphan.Path, false); err == nil && len(stats) > 0 {
+			orphan.Size = stats[0].Size
+			orphan.ModTime = stats[0].ModTime
+		}
+		if err := fn(orphan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListOrphanedDataHandler - scans a volume for data directories no xl.meta
+// references and streams them back, this is a read-only diagnostic.
+func (s *storageRESTServer) ListOrphanedDataHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	dirPath := r.Form.Get(storageRESTDirPath)
+
+	rw := streamHTTPResponse(w, "ListOrphanedData")
+	defer func() {
+		if rec := recover(); rec != nil {
+			debug.PrintStack()
+			rw.CloseWithError(fmt.Errorf("panic: %v", rec))
+		}
+	}()
+
+	mw := msgp.NewWriter(rw)
+	err := s.scanOrphanedData(r.Context(), volume, dirPath, func(info OrphanedDataInfo) error {
+		if err := info.EncodeMsg(mw); err != nil {
+			return err
+		}
+		return mw.Flush()
+	})
+	if err != nil {
+		rw.CloseWithError(err)
+		return
+	}
+	rw.CloseWithError(mw.Flush())
+}
+
+// ObjectTimestampsHandler - returns the object's derived creation time
+// (the earliest version's modtime) and latest modification time,
+// computed from the full version list in xl.meta.
+func (s *storageRESTServer) ObjectTimestampsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+
+	rf, err := s.getStorage().ReadXL(r.Context(), volume, filePath, false)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	fivs, err := getFileInfoVersions(rf.Buf, volume, filePath, true)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if len(fivs.Versions) == 0 {
+		s.writeErrorResponse(w, errFileNotFound)
+		return
+	}
+	var ts ObjectTimestamps
+	for _, fi := range fivs.Versions {
+		if ts.Created.IsZero() || fi.ModTime.Before(ts.Created) {
+			ts.Created = fi.ModTime
+		}
+		if fi.ModTime.After(ts.Modified) {
+			ts.Modified = fi.ModTime
+		}
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &ts))
+}
+
+// MerkleTreeHandler - computes a merkle tree over a file's contents
+// using the requested leaf size. When caching is requested the encoded
+// tree is stored next to the file and reused by later calls with a
+// matching leaf size.
+func (s *storageRESTServer) MerkleTreeHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+
+	leafSize := int64(merkleTreeDefaultLeafSize)
+	if v := r.Form.Get(storageRESTLeafSize); v != "" {
+		var err error
+		leafSize, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || leafSize <= 0 {
+			s.writeErrorResponse(w, errInvalidArgument)
+			return
+		}
+	}
+	cache := r.Form.Get(storageRESTCacheTree) == "true"
+
+	cachePath := filePath + merkleTreeCacheSuffix
+	if cache {
+		// Reuse a previously cached tree if it was computed with
+		// the same leaf size.
+		if buf, err := s.getStorage().ReadAll(r.Context(), volume, cachePath); err == nil {
+			var mt MerkleTreeInfo
+			if _, err = mt.UnmarshalMsg(buf); err == nil && mt.LeafSize == leafSize {
+				storageLogIf(r.Context(), msgp.Encode(w, &mt))
+				return
+			}
+		}
+	}
+
+	rc, err := s.getStorage().ReadFileStream(r.Context(), volume, filePath, 0, -1)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	defer rc.Close()
+
+	mt, err := computeMerkleTree(rc, leafSize)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	if cache {
+		buf, err := mt.MarshalMsg(nil)
+		if err == nil {
+			err = s.getStorage().WriteAll(r.Context(), volume, cachePath, buf)
+		}
+		if err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+	}
+
+	storageLogIf(r.Context(), msgp.Encode(w, &mt))
+}
+
+// RefreshDiskIDHandler - re-reads the drive's disk-id from format.json,
+// updates the in-memory caches and returns the fresh id. Only auth is
+// checked here, the request exists precisely because the cached id may
+// be stale after a re-format.
+func (s *storageRESTServer) RefreshDiskIDHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsAuthValid(w, r) {
+		return
+	}
+	storage := s.getStorage()
+	if storage == nil {
+		s.writeErrorResponse(w, errDiskNotFound)
+		return
+	}
+	var id string
+	var err error
+	if refresher, ok := storage.(interface{ RefreshDiskID() (string, error) }); ok {
+		id, err = refresher.RefreshDiskID()
+	} else {
+		id, err = storage.GetDiskID()
+	}
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	w.Header().Set(xhttp.ContentLength, strconv.Itoa(len(id)))
+	w.Write([]byte(id))
+}
+
+// GridHandlersHandler returns a handler listing the grid RPC handlers
+// registered on this node, one name per line followed by its subroute
+// if any. Useful to confirm a peer supports a given RPC before calling
+// it during rolling upgrades.
+func (s *storageRESTServer) GridHandlersHandler(gm *grid.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.IsAuthValid(w, r) {
+			return
+		}
+		registered := gm.RegisteredHandlers()
+		names := make([]string, 0, len(registered))
+		for name := range registered {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, subroute := range registered[name] {
+				if subroute == "" {
+					fmt.Fprintln(w, name)
+					continue
+				}
+				fmt.Fprintln(w, name, subroute)
+			}
+		}
+	}
+}
+
+// readRecordsMaxSize caps the file size served with record framing,
+// this mode buffers and re-frames the whole file server side.
+const readRecordsMaxSize = 8 << 20
+
+// ReadRecordsHandler - reads a file, splits it on a delimiter byte and
+// streams every record back prefixed with its little endian uint32
+// length. Intended for small text-based internal files only.
+func (s *storageRESTServer) ReadRecordsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	delim, err := strconv.Atoi(r.Form.Get(storageRESTDelimiter))
+	if err != nil || delim < 0 || delim > 255 {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	buf, err := s.getStorage().ReadAll(r.Context(), volume, filePath)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if len(buf) > readRecordsMaxSize {
+		s.writeErrorResponse(w, errDataTooLarge)
+		return
+	}
+	rw := streamHTTPResponse(w, "ReadRecords")
+	var hdr [4]byte
+	for _, rec := range bytes.Split(buf, []byte{byte(delim)}) {
+		binary.LittleEndian.PutUint32(hdr[:], uint32(len(rec)))
+		if _, err := rw.Write(hdr[:]); err != nil {
+			rw.CloseWithError(err)
+			return
+		}
+		if _, err := rw.Write(rec); err != nil {
+			rw.CloseWithError(err)
+			return
+		}
+	}
+	rw.CloseWithError(nil)
+}
+
+// BitrotErrorsHandler - returns the bounded list of bitrot errors
+// recently detected on this drive, this is a read-only diagnostic.
+func (s *storageRESTServer) BitrotErrorsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	rw := streamHTTPResponse(w, "BitrotErrors")
+	mw := msgp.NewWriter(rw)
+	for _, info := range bitrotErrorLogFor(s.endpoint.String()).list() {
+		if err := info.EncodeMsg(mw); err != nil {
+			rw.CloseWithError(err)
+			return
+		}
+	}
+	rw.CloseWithError(mw.Flush())
+}
+
+// listIncompleteUploads walks the drive's multipart staging area and
+// returns one entry per in-progress upload. With a non-empty shaFilter
+// only uploads for that "bucket/object" sha directory are returned.
+func listIncompleteUploads(ctx context.Context, store StorageAPI, shaFilter string) ([]IncompleteUploadInfo, error) {
+	shaDirs, err := store.ListDir(ctx, "", minioMetaMultipartBucket, "", -1)
+	if err != nil {
+		if errors.Is(err, errVolumeNotFound) || errors.Is(err, errFileNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var uploads []IncompleteUploadInfo
+	for _, shaDir := range shaDirs {
+		shaDir = strings.TrimSuffix(shaDir, SlashSeparator)
+		if shaFilter != "" && shaDir != shaFilter {
+			continue
+		}
+		uploadIDs, err := store.ListDir(ctx, "", minioMetaMultipartBucket, shaDir, -1)
+		if err != nil {
+			continue
+		}
+		for _, uploadID := range uploadIDs {
+			uploadID = strings.TrimSuffix(uploadID, SlashSeparator)
+			uploadIDPath := pathJoin(shaDir, uploadID)
+			info := IncompleteUploadInfo{ShaDir: shaDir, UploadID: uploadID}
+
+			// Upload IDs are of the form <UUID>x<UnixNano>, extract
+			// the initiation time from the suffix when present.
+			if split := strings.Split(uploadID, "x"); len(split) == 2 {
+				if t, err := strconv.ParseInt(split[1], 10, 64); err == nil {
+					info.Initiated = time.Unix(0, t).UTC()
+				}
+			}
+
+			fi, err := store.ReadVersion(ctx, "", minioMetaMultipartBucket, uploadIDPath, "", ReadOptions{})
+			if err != nil {
+				continue
+			}
+			if info.Initiated.IsZero() {
+				info.Initiated = fi.ModTime
+			}
+			if fi.DataDir != "" {
+				entries, err := store.ListDir(ctx, "", minioMetaMultipartBucket, pathJoin(uploadIDPath, fi.DataDir), -1)
+				if err == nil {
+					for _, entry := range entries {
+						var partNum int
+						if _, err := fmt.Sscanf(entry, "part.%d.meta", &partNum); err == nil {
+							info.Parts++
+						}
+					}
+				}
+			}
+			uploads = append(uploads, info)
+		}
+	}
+	return uploads, nil
+}
+
+// ListIncompleteUploadsHandler - streams the incomplete multipart
+// uploads found in this drive's staging area. With both volume and
+// file-path set, only uploads for that object are returned.
+func (s *storageRESTServer) ListIncompleteUploadsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	var shaFilter string
+	if volume != "" && filePath != "" {
+		shaFilter = getSHA256Hash([]byte(pathJoin(volume, filePath)))
+	}
+
+	uploads, err := listIncompleteUploads(r.Context(), s.getStorage(), shaFilter)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	rw := streamHTTPResponse(w, "ListIncompleteUploads")
+	mw := msgp.NewWriter(rw)
+	for _, info := range uploads {
+		if err := info.EncodeMsg(mw); err != nil {
+			rw.CloseWithError(err)
+			return
+		}
+	}
+	rw.CloseWithError(mw.Flush())
+}
+
+// SetFsyncPolicyHandler - configures the drive's fsync batching interval,
+// a zero interval restores the default sync-per-write behavior.
+func (s *storageRESTServer) SetFsyncPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	interval, err := time.ParseDuration(r.Form.Get(storageRESTInterval))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	fsyncBatcherFor(s.endpoint.String()).setInterval(interval)
+}
+
+// GetFsyncPolicyHandler - returns the drive's current fsync batching interval.
+func (s *storageRESTServer) GetFsyncPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	w.Write([]byte(fsyncBatcherFor(s.endpoint.String()).getInterval().String()))
+}
+
+// SetScanBudgetHandler - caps how many objects the drive's scanner
+// visits per cycle, zero removes the cap.
+func (s *storageRESTServer) SetScanBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	limit, err := strconv.ParseInt(r.Form.Get(storageRESTCount), 10, 64)
+	if err != nil || limit < 0 {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	scanBudgetFor(s.endpoint.String()).setLimit(limit)
+}
+
+// GetScanBudgetHandler - returns the drive's current per-cycle scan
+// budget, zero means unlimited.
+func (s *storageRESTServer) GetScanBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	w.Write([]byte(strconv.FormatInt(scanBudgetFor(s.endpoint.String()).getLimit(), 10)))
+}
+
+// RawPartPathsHandler - returns the absolute on-disk paths of a
+// version's part files and xl.meta for out-of-band tooling on the
+// same host. Paths resolving outside the drive root are refused.
+func (s *storageRESTServer) RawPartPathsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	versionID := r.Form.Get(storageRESTVersionID)
+
+	fi, err := s.getStorage().ReadVersion(r.Context(), "", volume, filePath, versionID, ReadOptions{})
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	root := path.Clean(s.endpoint.Path)
+	inRoot := func(p string) bool {
+		return strings.HasPrefix(path.Clean(p), root+SlashSeparator)
+	}
+	paths := RawPartPaths{
+		XLMeta: pathJoin(root, volume, filePath, xlStorageFormatFile),
+	}
+	if !fi.InlineData() && fi.DataDir != "" {
+		for _, part := range fi.Parts {
+			paths.Parts = append(paths.Parts, pathJoin(root, volume, filePath, fi.DataDir, fmt.Sprintf("part.%d", part.Number)))
+		}
+	}
+	if !inRoot(paths.XLMeta) {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	for _, p := range paths.Parts {
+		if !inRoot(p) {
+			s.writeErrorResponse(w, errInvalidArgument)
+			return
+		}
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &paths))
+}
+
+// StorageEfficiencyHandler - reports the ratio of an object's logical
+// bytes to the physical bytes consumed on this drive, from its version
+// metadata and a stat of the backing files. Erasure overhead, block
+// alignment and compression all show up as the gap between the two.
+func (s *storageRESTServer) StorageEfficiencyHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+
+	rf, err := s.getStorage().ReadXL(r.Context(), volume, filePath, false)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	fivs, err := getFileInfoVersions(rf.Buf, volume, filePath, false)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	var eff StorageEfficiency
+	if st, err := Lstat(pathJoin(s.endpoint.Path, volume, filePath, xlStorageFormatFile)); err == nil {
+		eff.PhysicalSize += st.Size()
+	}
+	for _, fi := range fivs.Versions {
+		if fi.Deleted {
+			continue
+		}
+		eff.LogicalSize += fi.Size
+		if fi.InlineData() || fi.DataDir == "" {
+			// Inline data is already accounted for by the xl.meta stat.
+			continue
+		}
+		for _, part := range fi.Parts {
+			st, err := Lstat(pathJoin(s.endpoint.Path, volume, filePath, fi.DataDir, fmt.Sprintf("part.%d", part.Number)))
+			if err != nil {
+				// Part file is gone or unreadable, fall back to the
+				// shard size implied by the metadata.
+				eff.PhysicalSize += fi.Erasure.ShardFileSize(part.Size)
+				continue
+			}
+			eff.PhysicalSize += st.Size()
+		}
+	}
+	if eff.LogicalSize > 0 {
+		eff.Ratio = float64(eff.PhysicalSize) / float64(eff.LogicalSize)
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &eff))
+}
+
+// LifecycleEvalHandler - evaluates the lifecycle configuration sent
+// in the request body against one object version and returns the
+// action the lifecycle engine would take. Dry-run by default; when
+// apply is requested an expiry outcome deletes the version.
+func (s *storageRESTServer) LifecycleEvalHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	versionID := r.Form.Get(storageRESTVersionID)
+
+	lc, err := lifecycle.ParseLifecycleConfig(r.Body)
+	if err != nil {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+
+	rf, err := s.getStorage().ReadXL(r.Context(), volume, filePath, false)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	fivs, err := getFileInfoVersions(rf.Buf, volume, filePath, true)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	idx := -1
+	for i, fi := range fivs.Versions {
+		if fi.VersionID == versionID || (versionID == "" && i == 0) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		s.writeErrorResponse(w, errFileVersionNotFound)
+		return
+	}
+	fi := fivs.Versions[idx]
+	opts := lifecycle.ObjectOpts{
+		Name:         filePath,
+		ModTime:      fi.ModTime,
+		Size:         fi.Size,
+		VersionID:    fi.VersionID,
+		IsLatest:     idx == 0,
+		DeleteMarker: fi.Deleted,
+		NumVersions:  len(fivs.Versions),
+	}
+	if idx > 0 {
+		opts.SuccessorModTime = fivs.Versions[idx-1].ModTime
+	}
+
+	event := lc.Eval(opts)
+	result := LifecycleEvalResult{RuleID: event.RuleID, Due: event.Due}
+	switch {
+	case event.Action.Delete():
+		result.Action = lifecycleEvalExpire
+	case event.Action == lifecycle.TransitionAction || event.Action == lifecycle.TransitionVersionAction:
+		result.Action = lifecycleEvalTransition
+	default:
+		result.Action = lifecycleEvalNone
+	}
+
+	if r.Form.Get(storageRESTApply) == "true" && result.Action == lifecycleEvalExpire {
+		if err = s.getStorage().DeleteVersion(r.Context(), volume, filePath, fi, false, DeleteOptions{}); err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &result))
+}
+
+// TaskQueueDepthHandler - returns how many background tasks of each
+// type are queued or running against this drive.
+func (s *storageRESTServer) TaskQueueDepthHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	depths := TaskQueueDepths{Depths: driveTaskQueueFor(s.endpoint.String()).snapshot()}
+	storageLogIf(r.Context(), msgp.Encode(w, &depths))
+}
+
+// DataDirsHandler - returns the distinct data directories referenced
+// by the object's versions, parsed from a single metadata read.
+func (s *storageRESTServer) DataDirsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+
+	rf, err := s.getStorage().ReadXL(r.Context(), volume, filePath, false)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	fivs, err := getFileInfoVersions(rf.Buf, volume, filePath, true)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	var info DataDirsInfo
+	seen := make(map[string]struct{}, len(fivs.Versions))
+	for _, fi := range fivs.Versions {
+		if fi.DataDir == "" {
+			continue
+		}
+		if _, ok := seen[fi.DataDir]; ok {
+			continue
+		}
+		seen[fi.DataDir] = struct{}{}
+		info.Dirs = append(info.Dirs, fi.DataDir)
+	}
+	info.Count = len(info.Dirs)
+	storageLogIf(r.Context(), msgp.Encode(w, &info))
+}
+
+// VersionsSummaryHandler - returns the number of live versions of an
+// object and their cumulative logical size, from a single metadata
+// read instead of shipping the whole version list.
+func (s *storageRESTServer) VersionsSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+
+	rf, err := s.getStorage().ReadXL(r.Context(), volume, filePath, false)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	fivs, err := getFileInfoVersions(rf.Buf, volume, filePath, false)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	var summary VersionsSummary
+	for _, fi := range fivs.Versions {
+		if fi.Deleted {
+			continue
+		}
+		summary.Count++
+		summary.TotalSize += fi.Size
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &summary))
+}
+
+// objectSetWalk recurses into dirPath collecting one hash per object
+// found, computed over the object path and its sorted version ids.
+func objectSetWalk(ctx context.Context, storage StorageAPI, volume, dirPath string, digest *ObjectSetDigest) error {
+	entries, err := storage.ListDir(ctx, "", volume, dirPath, -1)
+	if err != nil {
+		if errors.Is(err, errFileNotFound) {
+			return nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry, SlashSeparator) {
+			continue
+		}
+		child := pathJoin(dirPath, strings.TrimSuffix(entry, SlashSeparator))
+		buf, err := storage.ReadAll(ctx, volume, pathJoin(child, xlStorageFormatFile))
+		if err == nil {
+			fivs, err := getFileInfoVersions(buf, volume, child, false)
+			if err == nil {
+				vids := make([]string, 0, len(fivs.Versions))
+				for _, fi := range fivs.Versions {
+					vids = append(vids, fi.VersionID)
+				}
+				sort.Strings(vids)
+				h := xxh3.HashString(child + "\x00" + strings.Join(vids, ","))
+				digest.Entries = append(digest.Entries, h)
+			}
+		}
+		if err := objectSetWalk(ctx, storage, volume, child, digest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ObjectSetHandler - returns a compact digest of the objects this
+// drive holds under a volume, so a coordinator can diff a drive that
+// was offline against a healthy peer without shipping full listings.
+func (s *storageRESTServer) ObjectSetHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+
+	var digest ObjectSetDigest
+	if err := objectSetWalk(r.Context(), s.getStorage(), volume, "", &digest); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	sort.Slice(digest.Entries, func(i, j int) bool { return digest.Entries[i] < digest.Entries[j] })
+	digest.Count = len(digest.Entries)
+	for _, h := range digest.Entries {
+		digest.Digest ^= h
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &digest))
+}
+
+// MetaDigestHandler - returns the rolling metadata digest the scanner
+// last published for this drive, a cheap read of one value used by
+// cross-node consistency checks.
+func (s *storageRESTServer) MetaDigestHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	digest := driveMetaDigestFor(s.endpoint.String()).current()
+	storageLogIf(r.Context(), msgp.Encode(w, &digest))
+}
+
+// FillerStatsHandler - returns the node's keep-alive filler byte
+// counters, optionally resetting them. The counters are node-wide,
+// not per drive, so only authentication is required.
+func (s *storageRESTServer) FillerStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsAuthValid(w, r) {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	reset, _ := strconv.ParseBool(r.Form.Get(storageRESTReset))
+	stats := FillerByteStats{Counts: globalFillerByteStats.snapshot(reset)}
+	storageLogIf(r.Context(), msgp.Encode(w, &stats))
+}
+
+// versionIDReservationTTL is how long a reserved version-id stays
+// valid when not finalized by the upload that reserved it.
+const versionIDReservationTTL = 2 * time.Minute
+
+// Version-id reservations per object path. Expired entries are pruned
+// whenever a path is touched, so an abandoned upload cannot hold on
+// to its id forever.
+var globalVersionIDReservations = struct {
+	sync.Mutex
+	m map[string]map[string]time.Time // volume/path -> version-id -> expiry
+}{m: make(map[string]map[string]time.Time)}
+
+// reserveVersionID mints a version-id unique among the live
+// reservations for the given object path and records it.
+func reserveVersionID(volume, filePath string) string {
+	key := pathJoin(volume, filePath)
+	now := UTCNow()
+	globalVersionIDReservations.Lock()
+	defer globalVersionIDReservations.Unlock()
+	reserved := globalVersionIDReservations.m[key]
+	if reserved == nil {
+		reserved = make(map[string]time.Time)
+		globalVersionIDReservations.m[key] = reserved
+	}
+	for vid, expiry := range reserved {
+		if expiry.Before(now) {
+			delete(reserved, vid)
+		}
+	}
+	for {
+		vid := mustGetUUID()
+		if _, ok := reserved[vid]; ok {
+			continue
+		}
+		reserved[vid] = now.Add(versionIDReservationTTL)
+		return vid
+	}
+}
+
+// ReserveVersionIDHandler - mints and reserves a unique version-id
+// for an object path, so concurrent uploads coordinated through this
+// node cannot collide. The reservation expires if not finalized.
+func (s *storageRESTServer) ReserveVersionIDHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	if volume == "" || filePath == "" {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	w.Write([]byte(reserveVersionID(volume, filePath)))
+}
+
+// ScanErrorRateHandler - returns this drive's scan errors over the
+// sliding window, as a total and a per-minute average.
+func (s *storageRESTServer) ScanErrorRateHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	total, perMinute := scanErrorWindowFor(s.endpoint.String()).rateAt(time.Now())
+	rate := ScanErrorRate{
+		WindowMinutes: scanErrorWindowMinutes,
+		Total:         total,
+		PerMinute:     perMinute,
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &rate))
+}
+
+// Soft-deleted versions are parked under this volume until restored
+// or reaped after their recovery window lapses.
+const (
+	minioMetaTrashBucket = minioMetaBucket + "/trash"
+
+	trashExpiryMetaKey     = ReservedMetadataPrefixLower + "trash-expiry"
+	trashOrigVolumeMetaKey = ReservedMetadataPrefixLower + "trash-volume"
+	trashOrigPathMetaKey   = ReservedMetadataPrefixLower + "trash-path"
+
+	trashReapInterval = 5 * time.Minute
+)
+
+// A zero window keeps DeleteVersion deleting immediately.
+var globalSoftDeleteWindows = newDriveRegistry(func() *atomic.Int64 {
+	return new(atomic.Int64)
+})
+
+func softDeleteWindowFor(endpoint string) time.Duration {
+	return time.Duration(globalSoftDeleteWindows.get(endpoint).Load())
+}
+
+func setSoftDeleteWindow(endpoint string, window time.Duration) {
+	globalSoftDeleteWindows.get(endpoint).Store(int64(window))
+}
+
+// trashEntryName returns the trash directory for one soft-deleted
+// version, flat sha256 names keep lookups cheap for restores.
+func trashEntryName(volume, filePath, versionID string) string {
+	return getSHA256Hash([]byte(pathJoin(volume, filePath, versionID)))
+}
+
+// softDeleteVersion moves the version into the drive's trash area
+// instead of deleting it, recoverable until the window lapses.
+func (s *storageRESTServer) softDeleteVersion(ctx context.Context, volume, filePath string, fi FileInfo, forceDelMarker bool, window time.Duration) error {
+	storage := s.getStorage()
+	rfi, err := storage.ReadVersion(ctx, "", volume, filePath, fi.VersionID, ReadOptions{ReadData: true})
+	if err != nil {
+		return err
+	}
+
+	if err = storage.MakeVol(ctx, minioMetaTrashBucket); err != nil && !errors.Is(err, errVolumeExists) {
+		return err
+	}
+
+	entry := trashEntryName(volume, filePath, fi.VersionID)
+	if rfi.Metadata == nil {
+		rfi.Metadata = make(map[string]string)
+	}
+	rfi.Metadata[trashExpiryMetaKey] = UTCNow().Add(window).Format(time.RFC3339Nano)
+	rfi.Metadata[trashOrigVolumeMetaKey] = volume
+	rfi.Metadata[trashOrigPathMetaKey] = filePath
+	rfi.Volume = minioMetaTrashBucket
+	rfi.Name = entry
+	if err = storage.WriteMetadata(ctx, "", minioMetaTrashBucket, entry, rfi); err != nil {
+		return err
+	}
+	if !rfi.InlineData() && rfi.DataDir != "" {
+		if err = storage.RenameFile(ctx, volume, pathJoin(filePath, rfi.DataDir), minioMetaTrashBucket, pathJoin(entry, rfi.DataDir)); err != nil {
+			return err
+		}
+	}
+
+	return storage.DeleteVersion(ctx, volume, filePath, fi, forceDelMarker, DeleteOptions{})
+}
+
+// RestoreVersionHandler - moves a soft-deleted version out of the
+// trash area back to its original location.
+func (s *storageRESTServer) RestoreVersionHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	versionID := r.Form.Get(storageRESTVersionID)
+
+	storage := s.getStorage()
+	entry := trashEntryName(volume, filePath, versionID)
+	rfi, err := storage.ReadVersion(r.Context(), "", minioMetaTrashBucket, entry, versionID, ReadOptions{ReadData: true})
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	delete(rfi.Metadata, trashExpiryMetaKey)
+	delete(rfi.Metadata, trashOrigVolumeMetaKey)
+	delete(rfi.Metadata, trashOrigPathMetaKey)
+	rfi.Volume = volume
+	rfi.Name = filePath
+	if err = storage.WriteMetadata(r.Context(), "", volume, filePath, rfi); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if !rfi.InlineData() && rfi.DataDir != "" {
+		if err = storage.RenameFile(r.Context(), minioMetaTrashBucket, pathJoin(entry, rfi.DataDir), volume, pathJoin(filePath, rfi.DataDir)); err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+	}
+	if err = storage.Delete(r.Context(), minioMetaTrashBucket, entry, DeleteOptions{Recursive: true}); err != nil {
+		s.writeErrorResponse(w, err)
+	}
+}
+
+// SetSoftDeleteWindowHandler - configures how long soft-deleted
+// versions on this drive stay recoverable, zero disables soft delete.
+func (s *storageRESTServer) SetSoftDeleteWindowHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	window, err := time.ParseDuration(r.Form.Get(storageRESTInterval))
+	if err != nil || window < 0 {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	setSoftDeleteWindow(s.endpoint.String(), window)
+}
+
+// reapExpiredTrash purges trash entries on the drive whose recovery
+// window has lapsed.
+func reapExpiredTrash(ctx context.Context, storage StorageAPI) error {
+	entries, err := storage.ListDir(ctx, "", minioMetaTrashBucket, "", -1)
+	if err != nil {
+		if errors.Is(err, errVolumeNotFound) || errors.Is(err, errFileNotFound) {
+			return nil
+		}
+		return err
+	}
+	now := UTCNow()
+	for _, entry := range entries {
+		entry = strings.TrimSuffix(entry, SlashSeparator)
+		buf, err := storage.ReadAll(ctx, minioMetaTrashBucket, pathJoin(entry, xlStorageFormatFile))
+		if err != nil {
+			continue
+		}
+		fivs, err := getFileInfoVersions(buf, minioMetaTrashBucket, entry, true)
+		if err != nil {
+			continue
+		}
+		expired := len(fivs.Versions) > 0
+		for _, fi := range fivs.Versions {
+			expiry, err := time.Parse(time.RFC3339Nano, fi.Metadata[trashExpiryMetaKey])
+			if err != nil || expiry.After(now) {
+				expired = false
+				break
+			}
+		}
+		if expired {
+			storageLogIf(ctx, storage.Delete(ctx, minioMetaTrashBucket, entry, DeleteOptions{Recursive: true}))
+		}
+	}
+	return nil
+}
+
+var startTrashReaperOnce sync.Once
+
+// trashReaper periodically purges expired soft-deleted versions from
+// every local drive.
+func trashReaper(ctx context.Context) {
+	ticker := time.NewTicker(trashReapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			globalLocalDrivesMu.RLock()
+			localDrives := cloneDrives(globalLocalDrivesMap)
+			globalLocalDrivesMu.RUnlock()
+			for _, drive := range localDrives {
+				storageLogIf(ctx, reapExpiredTrash(ctx, drive))
+			}
+		}
+	}
+}
+
+// IsLatestVersionHandler - reports whether the given version-id is
+// currently the head version of the object, from a single metadata
+// read. Responds "true" or "false" in plain text.
+func (s *storageRESTServer) IsLatestVersionHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	versionID := r.Form.Get(storageRESTVersionID)
+
+	rf, err := s.getStorage().ReadXL(r.Context(), volume, filePath, false)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	fivs, err := getFileInfoVersions(rf.Buf, volume, filePath, true)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	found := false
+	for _, fi := range fivs.Versions {
+		if fi.VersionID == versionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		s.writeErrorResponse(w, errFileVersionNotFound)
+		return
+	}
+	w.Write([]byte(strconv.FormatBool(fivs.Versions[0].VersionID == versionID)))
+}
+
+// ErasureSetLayoutHandler - returns the pool/set/disk indices this
+// drive is configured with alongside the placement recorded in its
+// format.json, so a coordinator can detect drives mounted in the
+// wrong slot.
+func (s *storageRESTServer) ErasureSetLayoutHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	buf, err := s.getStorage().ReadAll(r.Context(), minioMetaBucket, formatConfigFile)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	format := &formatErasureV3{}
+	if err = json.Unmarshal(buf, format); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	layout := ErasureSetLayout{
+		PoolIdx:       s.endpoint.PoolIdx,
+		SetIdx:        s.endpoint.SetIdx,
+		DiskIdx:       s.endpoint.DiskIdx,
+		FormatSetIdx:  -1,
+		FormatDiskIdx: -1,
+		SetCount:      len(format.Erasure.Sets),
+		DiskID:        format.Erasure.This,
+	}
+	if layout.SetCount > 0 {
+		layout.SetDriveCount = len(format.Erasure.Sets[0])
+	}
+	for i := range format.Erasure.Sets {
+		for j := range format.Erasure.Sets[i] {
+			if format.Erasure.Sets[i][j] == format.Erasure.This {
+				layout.FormatSetIdx, layout.FormatDiskIdx = i, j
+			}
+		}
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &layout))
+}
+
+// SetTraceRateHandler - configures what percentage of this drive's
+// storage handler requests are traced, 100 traces every request.
+func (s *storageRESTServer) SetTraceRateHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	rate, err := strconv.ParseInt(r.Form.Get(storageRESTCount), 10, 64)
+	if err != nil || rate < 0 || rate > 100 {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	traceSamplerFor(s.endpoint.String()).setRate(rate)
+}
+
+// GetTraceRateHandler - returns the drive's current trace sampling
+// rate in percent.
+func (s *storageRESTServer) GetTraceRateHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	w.Write([]byte(strconv.FormatInt(traceSamplerFor(s.endpoint.String()).getRate(), 10)))
+}
 
-	_, err = xioutil.Copy(w, rc)
-	if !xnet.IsNetworkOrHostDown(err, true) { // do not need to log disconnected clients
-		storageLogIf(r.Context(), err)
+// CleanAbandonedDataHandler - Clean unused data directories.
+func (s *storageRESTServer) CleanAbandonedDataHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+	volume := r.Form.Get(storageRESTVolume)
+	filePath := r.Form.Get(storageRESTFilePath)
+	if volume == "" || filePath == "" {
+		return // Ignore
 	}
+	taskQueue := driveTaskQueueFor(s.endpoint.String())
+	taskQueue.enqueue(taskQueueCleanup)
+	defer taskQueue.dequeue(taskQueueCleanup)
+	keepHTTPResponseAlive(w, "CleanAbandonedData")(s.getStorage().CleanAbandonedData(r.Context(), volume, filePath))
 }
 
-// ListDirHandler - list a directory.
-func (s *storageRESTServer) ListDirHandler(ctx context.Context, params *grid.MSS, out chan<- *ListDirResult) *grid.RemoteErr {
-	if !s.checkID(params.Get(storageRESTDiskID)) {
-		return grid.NewRemoteErr(errDiskNotFound)
-	}
-	volume := params.Get(storageRESTVolume)
-	dirPath := params.Get(storageRESTDirPath)
-	origvolume := params.Get(storageRESTOrigVolume)
-	count, err := strconv.Atoi(params.Get(storageRESTCount))
+// Rehydrations in progress, keyed by volume, path and version, so a
+// second request while one is still running returns immediately.
+var (
+	globalRehydrateMu         sync.Mutex
+	globalRehydrateInProgress = make(map[string]struct{})
+)
+
+// rehydrateObject brings a transitioned object's data back to local
+// storage through the object layer. Objects whose data is already
+// local are a no-op, as are rehydrations already in progress.
+func (s *storageRESTServer) rehydrateObject(ctx context.Context, volume, filePath, versionID string) error {
+	fi, err := s.getStorage().ReadVersion(ctx, "", volume, filePath, versionID, ReadOptions{})
 	if err != nil {
-		return grid.NewRemoteErr(err)
+		return err
+	}
+	if !fi.IsRemote() {
+		// Data is already on local storage.
+		return nil
 	}
 
-	entries, err := s.getStorage().ListDir(ctx, origvolume, volume, dirPath, count)
-	if err != nil {
-		return grid.NewRemoteErr(err)
+	key := pathJoin(volume, filePath, versionID)
+	globalRehydrateMu.Lock()
+	if _, ok := globalRehydrateInProgress[key]; ok {
+		globalRehydrateMu.Unlock()
+		// Another caller is already rehydrating this version.
+		return nil
 	}
-	out <- &ListDirResult{Entries: entries}
-	return nil
-}
+	globalRehydrateInProgress[key] = struct{}{}
+	globalRehydrateMu.Unlock()
+	defer func() {
+		globalRehydrateMu.Lock()
+		delete(globalRehydrateInProgress, key)
+		globalRehydrateMu.Unlock()
+	}()
 
-// DeleteFileHandler - delete a file.
-func (s *storageRESTServer) DeleteFileHandler(p *DeleteFileHandlerParams) (grid.NoPayload, *grid.RemoteErr) {
-	if !s.checkID(p.DiskID) {
-		return grid.NewNPErr(errDiskNotFound)
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
 	}
-	return grid.NewNPErr(s.getStorage().Delete(context.Background(), p.Volume, p.FilePath, p.Opts))
+	return objAPI.RestoreTransitionedObject(ctx, volume, filePath, ObjectOptions{
+		VersionID: fi.VersionID,
+	})
 }
 
-// DeleteVersionsHandler - delete a set of a versions.
-func (s *storageRESTServer) DeleteVersionsHandler(w http.ResponseWriter, r *http.Request) {
+// RehydrateHandler - initiates rehydration of a tiered object's data
+// back to local storage so subsequent reads are served locally. The
+// response is kept alive with filler bytes while the remote tier is
+// read.
+func (s *storageRESTServer) RehydrateHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
 		return
 	}
-
 	volume := r.Form.Get(storageRESTVolume)
-	totalVersions, err := strconv.Atoi(r.Form.Get(storageRESTTotalVersions))
-	if err != nil {
-		s.writeErrorResponse(w, err)
+	filePath := r.Form.Get(storageRESTFilePath)
+	versionID := r.Form.Get(storageRESTVersionID)
+	if volume == "" || filePath == "" {
+		s.writeErrorResponse(w, errInvalidArgument)
 		return
 	}
+	keepHTTPResponseAlive(w, "Rehydrate")(s.rehydrateObject(r.Context(), volume, filePath, versionID))
+}
 
-	versions := make([]FileInfoVersions, totalVersions)
-	decoder := msgpNewReader(r.Body)
-	defer readMsgpReaderPoolPut(decoder)
-	for i := 0; i < totalVersions; i++ {
-		dst := &versions[i]
-		if err := dst.DecodeMsg(decoder); err != nil {
-			s.writeErrorResponse(w, err)
-			return
-		}
+// convertBitrotAlgorithm re-checksums an object's parts under a new
+// bitrot algorithm and rewrites the whole-part checksums kept in
+// fi.Erasure.Checksums. Each part is verified against its current
+// checksum in the same pass before the new checksum is trusted; a
+// corrupt part aborts the whole conversion with no metadata change.
+// Streaming bitrot (HighwayHash256S) embeds hashes in the data files,
+// so neither source nor target may be the streaming variant.
+func convertBitrotAlgorithm(ctx context.Context, store StorageAPI, volume, path, versionID string, algo BitrotAlgorithm) error {
+	if !algo.Available() || algo == HighwayHash256S {
+		return errInvalidArgument
 	}
 
-	done := keepHTTPResponseAlive(w)
-	opts := DeleteOptions{}
-	errs := s.getStorage().DeleteVersions(r.Context(), volume, versions, opts)
-	done(nil)
+	fi, err := store.ReadVersion(ctx, "", volume, path, versionID, ReadOptions{ReadData: true})
+	if err != nil {
+		return err
+	}
+	if len(fi.Erasure.Checksums) == 0 {
+		// Nothing to convert from - the object carries no
+		// whole-part checksums.
+		return errInvalidArgument
+	}
 
-	dErrsResp := &DeleteVersionsErrsResp{Errs: make([]string, totalVersions)}
-	for idx := range versions {
-		if errs[idx] != nil {
-			dErrsResp.Errs[idx] = errs[idx].Error()
+	converted := true
+	for _, part := range fi.Parts {
+		if fi.Erasure.GetChecksumInfo(part.Number).Algorithm != algo {
+			converted = false
+			break
 		}
 	}
+	if converted {
+		return nil
+	}
 
-	buf, _ := dErrsResp.MarshalMsg(nil)
-	w.Write(buf)
-}
+	newSums := make([]ChecksumInfo, 0, len(fi.Parts))
+	for _, part := range fi.Parts {
+		old := fi.Erasure.GetChecksumInfo(part.Number)
+		if !old.Algorithm.Available() || old.Algorithm == HighwayHash256S {
+			// GetChecksumInfo returns the streaming default when
+			// the part has no recorded whole-part checksum.
+			return errInvalidArgument
+		}
+		if len(old.Hash) == 0 {
+			return errFileCorrupt
+		}
 
-// RenameDataHandler - renames a meta object and data dir to destination.
-func (s *storageRESTServer) RenameDataHandler(p *RenameDataHandlerParams) (*RenameDataResp, *grid.RemoteErr) {
-	if !s.checkID(p.DiskID) {
-		return nil, grid.NewRemoteErr(errDiskNotFound)
+		var rc io.ReadCloser
+		if len(fi.Data) > 0 {
+			rc = io.NopCloser(bytes.NewReader(fi.Data))
+		} else {
+			partPath := pathJoin(path, fi.DataDir, fmt.Sprintf("part.%d", part.Number))
+			rc, err = store.ReadFileStream(ctx, volume, partPath, 0, -1)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Verify the old checksum and compute the new one in a
+		// single pass over the part.
+		oldHash, newHash := old.Algorithm.New(), algo.New()
+		_, err = io.Copy(io.MultiWriter(oldHash, newHash), rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(oldHash.Sum(nil), old.Hash) {
+			return errFileCorrupt
+		}
+		newSums = append(newSums, ChecksumInfo{
+			PartNumber: part.Number,
+			Algorithm:  algo,
+			Hash:       newHash.Sum(nil),
+		})
 	}
 
-	resp, err := s.getStorage().RenameData(context.Background(), p.SrcVolume, p.SrcPath, p.FI, p.DstVolume, p.DstPath, p.Opts)
-	return &resp, grid.NewRemoteErr(err)
+	fi.Erasure.Checksums = newSums
+	return store.WriteMetadata(ctx, "", volume, path, fi)
 }
 
-// RenameDataInlineHandler - renames a meta object and data dir to destination.
-func (s *storageRESTServer) RenameDataInlineHandler(p *RenameDataInlineHandlerParams) (*RenameDataResp, *grid.RemoteErr) {
-	defer p.Recycle()
-	return s.RenameDataHandler(&p.RenameDataHandlerParams)
-}
+// swapObjectData exchanges the contents of the latest versions of two
+// objects. Each object keeps its name, version ID and modification time;
+// everything describing the content - size, parts, checksums, inline data,
+// data directory and metadata including the ETag - moves with the data.
+//
+// The swap is not atomic: it is a sequence of renames followed by two
+// metadata writes. On error every completed step is rolled back, but a
+// crash in the middle can leave one object updated and the other not.
+func swapObjectData(ctx context.Context, store StorageAPI, volume, pathA, pathB string) error {
+	if pathA == pathB {
+		return nil
+	}
+	fiA, err := store.ReadVersion(ctx, "", volume, pathA, "", ReadOptions{ReadData: true})
+	if err != nil {
+		return err
+	}
+	fiB, err := store.ReadVersion(ctx, "", volume, pathB, "", ReadOptions{ReadData: true})
+	if err != nil {
+		return err
+	}
 
-// RenameFileHandler - rename a file from source to destination
-func (s *storageRESTServer) RenameFileHandler(p *RenameFileHandlerParams) (grid.NoPayload, *grid.RemoteErr) {
-	if !s.checkID(p.DiskID) {
-		return grid.NewNPErr(errDiskNotFound)
+	// Move the data directories across first, they are uuid named and
+	// cannot collide with anything under the peer object.
+	type move struct{ src, dst string }
+	var moved []move
+	rollbackMoves := func() {
+		for i := len(moved) - 1; i >= 0; i-- {
+			store.RenameFile(ctx, volume, moved[i].dst, volume, moved[i].src)
+		}
 	}
-	return grid.NewNPErr(s.getStorage().RenameFile(context.Background(), p.SrcVolume, p.SrcFilePath, p.DstVolume, p.DstFilePath))
+	moveDataDir := func(fromPath, toPath, dataDir string) error {
+		if dataDir == "" || dataDir == emptyUUID {
+			// Inline data has no data directory on disk.
+			return nil
+		}
+		src := pathJoin(fromPath, dataDir) + SlashSeparator
+		dst := pathJoin(toPath, dataDir) + SlashSeparator
+		if err := store.RenameFile(ctx, volume, src, volume, dst); err != nil {
+			return err
+		}
+		moved = append(moved, move{src: src, dst: dst})
+		return nil
+	}
+	if err = moveDataDir(pathA, pathB, fiA.DataDir); err != nil {
+		return err
+	}
+	if err = moveDataDir(pathB, pathA, fiB.DataDir); err != nil {
+		rollbackMoves()
+		return err
+	}
+
+	swapped := func(identity, content FileInfo) FileInfo {
+		fi := content
+		fi.Volume = identity.Volume
+		fi.Name = identity.Name
+		fi.VersionID = identity.VersionID
+		fi.ModTime = identity.ModTime
+		return fi
+	}
+	if err = store.WriteMetadata(ctx, "", volume, pathA, swapped(fiA, fiB)); err != nil {
+		rollbackMoves()
+		return err
+	}
+	if err = store.WriteMetadata(ctx, "", volume, pathB, swapped(fiB, fiA)); err != nil {
+		// Best effort: restore the first object's original metadata
+		// before undoing the data directory moves.
+		store.WriteMetadata(ctx, "", volume, pathA, fiA)
+		rollbackMoves()
+		return err
+	}
+	return nil
 }
 
-// RenamePartHandler - rename a multipart part from source to destination
-func (s *storageRESTServer) RenamePartHandler(p *RenamePartHandlerParams) (grid.NoPayload, *grid.RemoteErr) {
-	if !s.checkID(p.DiskID) {
-		return grid.NewNPErr(errDiskNotFound)
+// SwapDataHandler - exchange the contents of the latest versions of two
+// objects. See swapObjectData for the exact semantics and atomicity limits.
+func (s *storageRESTServer) SwapDataHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
 	}
-	return grid.NewNPErr(s.getStorage().RenamePart(context.Background(), p.SrcVolume, p.SrcFilePath, p.DstVolume, p.DstFilePath, p.Meta))
+	volume := r.Form.Get(storageRESTVolume)
+	srcPath := r.Form.Get(storageRESTSrcPath)
+	dstPath := r.Form.Get(storageRESTDstPath)
+	if volume == "" || srcPath == "" || dstPath == "" {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+	keepHTTPResponseAlive(w, "SwapData")(swapObjectData(r.Context(), s.getStorage(), volume, srcPath, dstPath))
 }
 
-// CleanAbandonedDataHandler - Clean unused data directories.
-func (s *storageRESTServer) CleanAbandonedDataHandler(w http.ResponseWriter, r *http.Request) {
+// ConvertBitrotHandler - re-checksums an object's parts under a new
+// bitrot algorithm, updating only the checksum metadata.
+func (s *storageRESTServer) ConvertBitrotHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
 		return
 	}
 	volume := r.Form.Get(storageRESTVolume)
 	filePath := r.Form.Get(storageRESTFilePath)
-	if volume == "" || filePath == "" {
-		return // Ignore
-	}
-	keepHTTPResponseAlive(w)(s.getStorage().CleanAbandonedData(r.Context(), volume, filePath))
+	versionID := r.Form.Get(storageRESTVersionID)
+	algo := BitrotAlgorithmFromString(r.Form.Get(storageRESTBitrotAlgo))
+	keepHTTPResponseAlive(w, "ConvertBitrot")(convertBitrotAlgorithm(r.Context(), s.getStorage(), volume, filePath, versionID, algo))
 }
 
 // closeNotifier is itself a ReadCloser that will notify when either an error occurs or
@@ -765,15 +3414,58 @@ func (c *closeNotifier) Close() error {
 	return c.rc.Close()
 }
 
+// keepAliveInterval is how often a filler space character is sent on
+// keep-alive responses while a long storage operation is in progress.
+// The 10s default matches previous releases; it may be lowered when a
+// fronting load balancer enforces a shorter idle timeout.
+var keepAliveInterval = time.Second * 10
+
+// keepAliveFirstByte is how long to wait before the first filler byte is
+// sent, so connections survive idle timeouts shorter than keepAliveInterval.
+var keepAliveFirstByte = time.Second
+
+// fillerByteStats counts keep-alive filler bytes sent per handler.
+// Filler bytes are only written while an operation has not produced
+// its response yet, so the counters are a coarse proxy for slow
+// storage operations.
+type fillerByteStats struct {
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+func (f *fillerByteStats) add(handler string, n uint64) {
+	f.mu.Lock()
+	f.counts[handler] += n
+	f.mu.Unlock()
+}
+
+// snapshot returns a copy of the current counters, clearing them when
+// reset is set.
+func (f *fillerByteStats) snapshot(reset bool) map[string]uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	counts := make(map[string]uint64, len(f.counts))
+	for k, v := range f.counts {
+		counts[k] = v
+	}
+	if reset {
+		f.counts = make(map[string]uint64)
+	}
+	return counts
+}
+
+var globalFillerByteStats = &fillerByteStats{counts: make(map[string]uint64)}
+
 // keepHTTPReqResponseAlive can be used to avoid timeouts with long storage
 // operations, such as bitrot verification or data usage scanning.
-// Every 10 seconds a space character is sent.
+// A space character is sent shortly after start and then every keepAliveInterval.
 // keepHTTPReqResponseAlive will wait for the returned body to be read before starting the ticker.
 // The returned function should always be called to release resources.
 // An optional error can be sent which will be picked as text only error,
 // without its original type by the receiver.
 // waitForHTTPResponse should be used to the receiving side.
-func keepHTTPReqResponseAlive(w http.ResponseWriter, r *http.Request) (resp func(error), body io.ReadCloser) {
+// Filler bytes are counted against handler in globalFillerByteStats.
+func keepHTTPReqResponseAlive(w http.ResponseWriter, r *http.Request, handler string) (resp func(error), body io.ReadCloser) {
 	bodyDoneCh := make(chan struct{})
 	doneCh := make(chan error)
 	ctx := r.Context()
@@ -794,7 +3486,7 @@ func keepHTTPReqResponseAlive(w http.ResponseWriter, r *http.Request) (resp func
 		case err := <-doneCh:
 			if err != nil {
 				write([]byte{1})
-				write([]byte(err.Error()))
+				write([]byte(storageErrEncode(err)))
 			} else {
 				write([]byte{0})
 			}
@@ -802,13 +3494,16 @@ func keepHTTPReqResponseAlive(w http.ResponseWriter, r *http.Request) (resp func
 			return
 		}
 		defer xioutil.SafeClose(doneCh)
-		// Initiate ticker after body has been read.
-		ticker := time.NewTicker(time.Second * 10)
-		defer ticker.Stop()
+		// Initiate timer after body has been read.
+		// The first filler byte is sent early so aggressive idle
+		// timeouts do not kill the connection before the first tick.
+		timer := time.NewTimer(keepAliveFirstByte)
+		defer timer.Stop()
 
 		for {
 			select {
-			case <-ticker.C:
+			case <-timer.C:
+				timer.Reset(keepAliveInterval)
 				// The done() might have been called
 				// concurrently, check for it before we
 				// write the filler byte.
@@ -816,7 +3511,7 @@ func keepHTTPReqResponseAlive(w http.ResponseWriter, r *http.Request) (resp func
 				case err := <-doneCh:
 					if err != nil {
 						write([]byte{1})
-						write([]byte(err.Error()))
+						write([]byte(storageErrEncode(err)))
 					} else {
 						write([]byte{0})
 					}
@@ -827,12 +3522,13 @@ func keepHTTPReqResponseAlive(w http.ResponseWriter, r *http.Request) (resp func
 				// Response not ready, write a filler byte.
 				write([]byte{32})
 				if canWrite {
+					globalFillerByteStats.add(handler, 1)
 					w.(http.Flusher).Flush()
 				}
 			case err := <-doneCh:
 				if err != nil {
 					write([]byte{1})
-					write([]byte(err.Error()))
+					write([]byte(storageErrEncode(err)))
 				} else {
 					write([]byte{0})
 				}
@@ -860,12 +3556,13 @@ func keepHTTPReqResponseAlive(w http.ResponseWriter, r *http.Request) (resp func
 // operations, such as bitrot verification or data usage scanning.
 // keepHTTPResponseAlive may NOT be used until the request body has been read,
 // use keepHTTPReqResponseAlive instead.
-// Every 10 seconds a space character is sent.
+// A space character is sent shortly after start and then every keepAliveInterval.
 // The returned function should always be called to release resources.
 // An optional error can be sent which will be picked as text only error,
 // without its original type by the receiver.
 // waitForHTTPResponse should be used to the receiving side.
-func keepHTTPResponseAlive(w http.ResponseWriter) func(error) {
+// Filler bytes are counted against handler in globalFillerByteStats.
+func keepHTTPResponseAlive(w http.ResponseWriter, handler string) func(error) {
 	doneCh := make(chan error)
 	go func() {
 		canWrite := true
@@ -878,11 +3575,14 @@ func keepHTTPResponseAlive(w http.ResponseWriter) func(error) {
 			}
 		}
 		defer xioutil.SafeClose(doneCh)
-		ticker := time.NewTicker(time.Second * 10)
-		defer ticker.Stop()
+		// The first filler byte is sent early so aggressive idle
+		// timeouts do not kill the connection before the first tick.
+		timer := time.NewTimer(keepAliveFirstByte)
+		defer timer.Stop()
 		for {
 			select {
-			case <-ticker.C:
+			case <-timer.C:
+				timer.Reset(keepAliveInterval)
 				// The done() might have been called
 				// concurrently, check for it before we
 				// write the filler byte.
@@ -890,7 +3590,7 @@ func keepHTTPResponseAlive(w http.ResponseWriter) func(error) {
 				case err := <-doneCh:
 					if err != nil {
 						write([]byte{1})
-						write([]byte(err.Error()))
+						write([]byte(storageErrEncode(err)))
 					} else {
 						write([]byte{0})
 					}
@@ -901,12 +3601,13 @@ func keepHTTPResponseAlive(w http.ResponseWriter) func(error) {
 				// Response not ready, write a filler byte.
 				write([]byte{32})
 				if canWrite {
+					globalFillerByteStats.add(handler, 1)
 					w.(http.Flusher).Flush()
 				}
 			case err := <-doneCh:
 				if err != nil {
 					write([]byte{1})
-					write([]byte(err.Error()))
+					write([]byte(storageErrEncode(err)))
 				} else {
 					write([]byte{0})
 				}
@@ -993,12 +3694,13 @@ func (h *httpStreamResponse) CloseWithError(err error) {
 
 // streamHTTPResponse can be used to avoid timeouts with long storage
 // operations, such as bitrot verification or data usage scanning.
-// Every 10 seconds a space character is sent.
+// A space character is sent shortly after start and then every keepAliveInterval.
 // The returned function should always be called to release resources.
 // An optional error can be sent which will be picked as text only error,
 // without its original type by the receiver.
 // waitForHTTPStream should be used to the receiving side.
-func streamHTTPResponse(w http.ResponseWriter) *httpStreamResponse {
+// Filler bytes are counted against handler in globalFillerByteStats.
+func streamHTTPResponse(w http.ResponseWriter, handler string) *httpStreamResponse {
 	doneCh := make(chan error)
 	blockCh := make(chan []byte)
 	h := httpStreamResponse{done: doneCh, block: blockCh}
@@ -1013,20 +3715,24 @@ func streamHTTPResponse(w http.ResponseWriter) *httpStreamResponse {
 			}
 		}
 
-		ticker := time.NewTicker(time.Second * 10)
-		defer ticker.Stop()
+		// The first filler byte is sent early so aggressive idle
+		// timeouts do not kill the connection before the first tick.
+		timer := time.NewTimer(keepAliveFirstByte)
+		defer timer.Stop()
 		for {
 			select {
-			case <-ticker.C:
+			case <-timer.C:
+				timer.Reset(keepAliveInterval)
 				// Response not ready, write a filler byte.
 				write([]byte{32})
 				if canWrite {
+					globalFillerByteStats.add(handler, 1)
 					w.(http.Flusher).Flush()
 				}
 			case err := <-doneCh:
 				if err != nil {
 					write([]byte{1})
-					write([]byte(err.Error()))
+					write([]byte(storageErrEncode(err)))
 				} else {
 					write([]byte{0})
 				}
@@ -1127,13 +3833,46 @@ func (s *storageRESTServer) VerifyFileHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	payload, err := readMsgpPayload(r.Body, 1)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
 	var fi FileInfo
-	if err := msgp.Decode(r.Body, &fi); err != nil {
+	if _, err := fi.UnmarshalMsg(payload); err != nil {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+
+	gate := driveHeavyGateFor(s.endpoint.String())
+	if err := gate.acquire(r.Context()); err != nil {
 		s.writeErrorResponse(w, err)
 		return
 	}
+	defer gate.release()
+
+	if r.Form.Get(storageRESTProgress) == "true" {
+		// Progress-aware callers get one record per verified part
+		// followed by a terminal record carrying the final result.
+		rw := streamHTTPResponse(w, "VerifyFile")
+		ctx := withVerifyFileProgress(r.Context(), func(part int, bytesDone int64) {
+			rec := VerifyFileProgress{Part: part, BytesDone: bytesDone}
+			buf, _ := rec.MarshalMsg(nil)
+			rw.Write(buf)
+		})
+		resp, err := s.getStorage().VerifyFile(ctx, volume, filePath, fi)
+		if err != nil {
+			rw.CloseWithError(err)
+			return
+		}
+		final := VerifyFileProgress{Done: true, Resp: resp}
+		buf, _ := final.MarshalMsg(nil)
+		rw.Write(buf)
+		rw.CloseWithError(nil)
+		return
+	}
 
-	done := keepHTTPResponseAlive(w)
+	done := keepHTTPResponseAlive(w, "VerifyFile")
 	resp, err := s.getStorage().VerifyFile(r.Context(), volume, filePath, fi)
 	done(err)
 	if err != nil {
@@ -1255,7 +3994,7 @@ func (s *storageRESTServer) StatInfoFile(w http.ResponseWriter, r *http.Request)
 	volume := r.Form.Get(storageRESTVolume)
 	filePath := r.Form.Get(storageRESTFilePath)
 	glob := r.Form.Get(storageRESTGlob)
-	done := keepHTTPResponseAlive(w)
+	done := keepHTTPResponseAlive(w, "StatInfoFile")
 	stats, err := s.getStorage().StatInfoFile(r.Context(), volume, filePath, glob == "true")
 	done(err)
 	if err != nil {
@@ -1266,6 +4005,86 @@ func (s *storageRESTServer) StatInfoFile(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// metaMutateMu serializes transactional metadata mutations on this
+// node so concurrent transactions cannot interleave between the read
+// and the write of xl.meta.
+var metaMutateMu sync.Mutex
+
+// mutateMetadata validates and applies a list of metadata mutations to
+// one version all-or-nothing: every op is checked before the first one
+// is applied, and the result is persisted with a single atomic xl.meta
+// rewrite. The resulting metadata is returned.
+func mutateMetadata(ctx context.Context, store StorageAPI, req *MetaMutationReq) (map[string]string, error) {
+	if len(req.Ops) == 0 {
+		return nil, errInvalidArgument
+	}
+	for _, op := range req.Ops {
+		if op.Key == "" {
+			return nil, errInvalidArgument
+		}
+		switch op.Op {
+		case metaMutationSet, metaMutationDelete:
+		default:
+			return nil, errInvalidArgument
+		}
+	}
+
+	metaMutateMu.Lock()
+	defer metaMutateMu.Unlock()
+
+	fi, err := store.ReadVersion(ctx, "", req.Volume, req.FilePath, req.VersionID, ReadOptions{ReadData: true})
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]string, len(fi.Metadata)+len(req.Ops))
+	for k, v := range fi.Metadata {
+		metadata[k] = v
+	}
+	for _, op := range req.Ops {
+		switch op.Op {
+		case metaMutationSet:
+			metadata[op.Key] = op.Value
+		case metaMutationDelete:
+			delete(metadata, op.Key)
+		}
+	}
+
+	fi.Metadata = metadata
+	if err = store.WriteMetadata(ctx, "", req.Volume, req.FilePath, fi); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// MutateMetadataHandler - applies a list of metadata mutations to one
+// version all-or-nothing and returns the resulting metadata.
+func (s *storageRESTServer) MutateMetadataHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+
+	var req MetaMutationReq
+	mr := msgpNewReader(r.Body)
+	defer readMsgpReaderPoolPut(mr)
+
+	if err := req.DecodeMsg(mr); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if err := checkStoragePaths(req.Volume, req.FilePath); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	metadata, err := mutateMetadata(r.Context(), s.getStorage(), &req)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &MetaMutationResp{Metadata: metadata}))
+}
+
 func (s *storageRESTServer) DeleteBulkHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
 		return
@@ -1280,8 +4099,13 @@ func (s *storageRESTServer) DeleteBulkHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err := checkStoragePaths(req.Paths...); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
 	volume := r.Form.Get(storageRESTVolume)
-	keepHTTPResponseAlive(w)(s.getStorage().DeleteBulk(r.Context(), volume, req.Paths...))
+	keepHTTPResponseAlive(w, "DeleteBulk")(s.getStorage().DeleteBulk(r.Context(), volume, req.Paths...))
 }
 
 // ReadMultiple returns multiple files
@@ -1289,7 +4113,13 @@ func (s *storageRESTServer) ReadMultiple(w http.ResponseWriter, r *http.Request)
 	if !s.IsValid(w, r) {
 		return
 	}
-	rw := streamHTTPResponse(w)
+	gate := driveHeavyGateFor(s.endpoint.String())
+	if err := gate.acquire(r.Context()); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	defer gate.release()
+	rw := streamHTTPResponse(w, "ReadMultiple")
 	defer func() {
 		if r := recover(); r != nil {
 			debug.PrintStack()
@@ -1332,10 +4162,152 @@ func (s *storageRESTServer) ReadMultiple(w http.ResponseWriter, r *http.Request)
 // Any updates to this must be serialized via globalLocalDrivesMu (locker)
 var globalLocalSetDrives [][][]StorageAPI
 
+// Initialization states reported by DriveInitStateHandler for drives
+// going through asynchronous creation in registerStorageRESTHandlers.
+const (
+	driveInitReady    = "ready"
+	driveInitRetrying = "retrying"
+	driveInitFailed   = "failed"
+)
+
+var (
+	globalDriveInitMu     sync.Mutex
+	globalDriveInitStates = make(map[string]DriveInitState)
+)
+
+// setDriveInitState records the initialization state of the drive at
+// the given endpoint so operators can query why it is not serving.
+func setDriveInitState(endpoint Endpoint, state string, err error) {
+	st := DriveInitState{Endpoint: endpoint.String(), State: state}
+	if err != nil {
+		st.LastErr = err.Error()
+	}
+	globalDriveInitMu.Lock()
+	globalDriveInitStates[st.Endpoint] = st
+	globalDriveInitMu.Unlock()
+}
+
+// DriveInitStateHandler - reports whether this drive is serving, still
+// retrying initialization, or failed permanently, along with the last
+// initialization error. Only auth is checked: a drive that is still
+// initializing has no valid storage layer yet.
+func (s *storageRESTServer) DriveInitStateHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate auth directly - IsAuthValid additionally requires an
+	// installed storage layer, which is exactly what a pending drive
+	// does not have.
+	if err := storageServerRequestValidate(r); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	globalDriveInitMu.Lock()
+	st, ok := globalDriveInitStates[s.endpoint.String()]
+	globalDriveInitMu.Unlock()
+	if !ok {
+		// No recorded attempt, e.g. storage installed directly in
+		// tests; derive the state from storage presence.
+		st = DriveInitState{Endpoint: s.endpoint.String(), State: driveInitRetrying}
+		if s.getStorage() != nil {
+			st.State = driveInitReady
+		}
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &st))
+}
+
+// createStorage (re)initializes the local storage layer for the drive
+// at the given endpoint and installs it in the global drive tables.
+// Safe to call concurrently with itself for the same endpoint, the
+// last successful initialization wins.
+func createStorage(endpoint Endpoint) bool {
+	xl, err := newXLStorage(endpoint, false)
+	if err != nil {
+		// if supported errors don't fail, we proceed to
+		// printing message and moving forward.
+		if errors.Is(err, errDriveIsRoot) {
+			err = fmt.Errorf("major: %v: minor: %v: %w", xl.major, xl.minor, err)
+		}
+		state := driveInitRetrying
+		switch {
+		case errors.Is(err, errUnsupportedDisk), errors.Is(err, errDriveIsRoot), errors.Is(err, errDiskNotDir):
+			// These do not self-heal, retrying will not
+			// bring the drive online without operator
+			// intervention.
+			state = driveInitFailed
+		}
+		setDriveInitState(endpoint, state, err)
+		logFatalErrs(err, endpoint, false)
+		return false
+	}
+	storage := newXLStorageDiskIDCheck(xl, true)
+	storage.SetDiskID(xl.diskID)
+	// We do not have to do SetFormatData() since 'xl'
+	// already captures formatData cached.
+
+	globalLocalDrivesMu.Lock()
+	defer globalLocalDrivesMu.Unlock()
+
+	globalLocalDrivesMap[endpoint.String()] = storage
+	globalLocalSetDrives[endpoint.PoolIdx][endpoint.SetIdx][endpoint.DiskIdx] = storage
+	setDriveInitState(endpoint, driveInitReady, nil)
+	return true
+}
+
+// ReloadDriveHandler - force an immediate re-initialization of this
+// endpoint's storage layer, e.g. right after an operator hot-swapped
+// the physical drive, instead of waiting for the background retry
+// loop. Idempotent: a drive that is already serving is left untouched.
+// Responds with the resulting init state so the caller learns whether
+// the drive came online.
+func (s *storageRESTServer) ReloadDriveHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate auth directly - IsAuthValid additionally requires an
+	// installed storage layer, which a dead drive does not have.
+	if err := storageServerRequestValidate(r); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	reinit := true
+	if storage := s.getStorage(); storage != nil {
+		if _, err := storage.GetDiskID(); err == nil || errors.Is(err, errUnformattedDisk) {
+			// Drive is reachable (an unformatted one is simply
+			// awaiting format), do not yank it out from under
+			// in-flight requests.
+			reinit = false
+		}
+	}
+	if reinit {
+		createStorage(s.endpoint)
+	}
+	globalDriveInitMu.Lock()
+	st, ok := globalDriveInitStates[s.endpoint.String()]
+	globalDriveInitMu.Unlock()
+	if !ok {
+		st = DriveInitState{Endpoint: s.endpoint.String(), State: driveInitRetrying}
+		if s.getStorage() != nil {
+			st.State = driveInitReady
+		}
+	}
+	storageLogIf(r.Context(), msgp.Encode(w, &st))
+}
+
 // registerStorageRESTHandlers - register storage rpc router.
 func registerStorageRESTHandlers(router *mux.Router, endpointServerPools EndpointServerPools, gm *grid.Manager) {
-	h := func(f http.HandlerFunc) http.HandlerFunc {
-		return collectInternodeStats(httpTraceHdrs(f))
+	startTrashReaperOnce.Do(func() {
+		go trashReaper(GlobalContext)
+	})
+
+	hFor := func(endpoint Endpoint) func(http.HandlerFunc) http.HandlerFunc {
+		return func(f http.HandlerFunc) http.HandlerFunc {
+			inner := collectInternodeStats(endpoint.String(), httpTraceSampledHdrs(endpoint.String(), f))
+			return func(w http.ResponseWriter, r *http.Request) {
+				// Honor the caller's deadline so a slow disk operation
+				// is abandoned once the caller has given up waiting.
+				if deadline, ok := parseStorageDeadline(r.Header.Get(xhttp.MinIODeadline)); ok {
+					ctx, cancel := context.WithDeadline(r.Context(), deadline)
+					defer cancel()
+					r = r.WithContext(ctx)
+				}
+				inner(w, r)
+			}
+		}
 	}
 
 	globalLocalDrivesMap = make(map[string]StorageAPI)
@@ -1356,6 +4328,8 @@ func registerStorageRESTHandlers(router *mux.Router, endpointServerPools Endpoin
 				endpoint: endpoint,
 			}
 
+			h := hFor(endpoint)
+
 			subrouter := router.PathPrefix(path.Join(storageRESTPrefix, endpoint.Path)).Subrouter()
 
 			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodHealth).HandlerFunc(h(server.HealthHandler))
@@ -1368,59 +4342,85 @@ func registerStorageRESTHandlers(router *mux.Router, endpointServerPools Endpoin
 			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodCleanAbandoned).HandlerFunc(h(server.CleanAbandonedDataHandler))
 			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodDeleteBulk).HandlerFunc(h(server.DeleteBulkHandler))
 			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodReadParts).HandlerFunc(h(server.ReadPartsHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodListOrphans).HandlerFunc(h(server.ListOrphanedDataHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodSetFsyncPolicy).HandlerFunc(h(server.SetFsyncPolicyHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodOpenReadSess).HandlerFunc(h(server.OpenReadSessionHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodCloseReadSess).HandlerFunc(h(server.CloseReadSessionHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodSwapData).HandlerFunc(h(server.SwapDataHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodRefreshDiskID).HandlerFunc(h(server.RefreshDiskIDHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodConvertBitrot).HandlerFunc(h(server.ConvertBitrotHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodSetScanBudget).HandlerFunc(h(server.SetScanBudgetHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodMutateMeta).HandlerFunc(h(server.MutateMetadataHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodSetTraceRate).HandlerFunc(h(server.SetTraceRateHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodRestoreVersion).HandlerFunc(h(server.RestoreVersionHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodSetTrashWindow).HandlerFunc(h(server.SetSoftDeleteWindowHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodLifecycleEval).HandlerFunc(h(server.LifecycleEvalHandler))
+
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodGetFsyncPolicy).HandlerFunc(h(server.GetFsyncPolicyHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodBitrotErrors).HandlerFunc(h(server.BitrotErrorsHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodReadRecords).HandlerFunc(h(server.ReadRecordsHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodGridHandlers).HandlerFunc(h(server.GridHandlersHandler(gm)))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodReadSess).HandlerFunc(h(server.ReadSessionHandler))
 
 			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodReadFileStream).HandlerFunc(h(server.ReadFileStreamHandler))
 			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodReadVersion).HandlerFunc(h(server.ReadVersionHandler))
 			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodReadXL).HandlerFunc(h(server.ReadXLHandler))
 			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodReadFile).HandlerFunc(h(server.ReadFileHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodReadWeakETag).HandlerFunc(h(server.ReadWeakETagHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodObjectTimes).HandlerFunc(h(server.ObjectTimestampsHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodMerkleTree).HandlerFunc(h(server.MerkleTreeHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodInitState).HandlerFunc(h(server.DriveInitStateHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodIncompleteUp).HandlerFunc(h(server.ListIncompleteUploadsHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodGetScanBudget).HandlerFunc(h(server.GetScanBudgetHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodReadAll).HandlerFunc(h(server.ReadAllHTTPHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodWriteAll).HandlerFunc(h(server.WriteAllHTTPHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodGetTraceRate).HandlerFunc(h(server.GetTraceRateHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodSetLayout).HandlerFunc(h(server.ErasureSetLayoutHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodIsLatest).HandlerFunc(h(server.IsLatestVersionHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodDataDirs).HandlerFunc(h(server.DataDirsHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodVolExists).HandlerFunc(h(server.VolExistsHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodTaskQueues).HandlerFunc(h(server.TaskQueueDepthHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodRawPartPaths).HandlerFunc(h(server.RawPartPathsHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodStorageEff).HandlerFunc(h(server.StorageEfficiencyHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodVersionsSumm).HandlerFunc(h(server.VersionsSummaryHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodObjectSet).HandlerFunc(h(server.ObjectSetHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodFillerStats).HandlerFunc(h(server.FillerStatsHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodRehydrate).HandlerFunc(h(server.RehydrateHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodScanErrRate).HandlerFunc(h(server.ScanErrorRateHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodReadTransform).HandlerFunc(h(server.ReadTransformedHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodReserveVID).HandlerFunc(h(server.ReserveVersionIDHandler))
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodReloadDrive).HandlerFunc(h(server.ReloadDriveHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodShardLayout).HandlerFunc(h(server.ShardLayoutHandler))
+			subrouter.Methods(http.MethodGet).Path(storageRESTVersionPrefix + storageRESTMethodMetaDigest).HandlerFunc(h(server.MetaDigestHandler))
 
 			logger.FatalIf(storageListDirRPC.RegisterNoInput(gm, server.ListDirHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageReadAllRPC.Register(gm, server.ReadAllHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageWriteAllRPC.Register(gm, server.WriteAllHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageRenameFileRPC.Register(gm, server.RenameFileHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageRenamePartRPC.Register(gm, server.RenamePartHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageRenameDataRPC.Register(gm, server.RenameDataHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageRenameDataInlineRPC.Register(gm, server.RenameDataInlineHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageDeleteFileRPC.Register(gm, server.DeleteFileHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageCheckPartsRPC.Register(gm, server.CheckPartsHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageReadVersionRPC.Register(gm, server.ReadVersionHandlerWS, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageWriteMetadataRPC.Register(gm, server.WriteMetadataHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageUpdateMetadataRPC.Register(gm, server.UpdateMetadataHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageDeleteVersionRPC.Register(gm, server.DeleteVersionHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageReadXLRPC.Register(gm, server.ReadXLHandlerWS, endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageListDirBudgetRPC.RegisterNoInput(gm, server.ListDirBudgetHandler, endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageListDirChunkedRPC.RegisterNoInput(gm, server.ListDirChunkedHandler, endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageReadAllRPC.Register(gm, gridInstrument(endpoint.String(), "ReadAll", server.ReadAllHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageWriteAllRPC.Register(gm, gridInstrument(endpoint.String(), "WriteAll", server.WriteAllHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageRenameFileRPC.Register(gm, gridInstrument(endpoint.String(), "RenameFile", server.RenameFileHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageRenamePartRPC.Register(gm, gridInstrument(endpoint.String(), "RenamePart", server.RenamePartHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageRenameDataRPC.Register(gm, gridInstrument(endpoint.String(), "RenameData", server.RenameDataHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageRenameDataInlineRPC.Register(gm, gridInstrument(endpoint.String(), "RenameDataInline", server.RenameDataInlineHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageDeleteFileRPC.Register(gm, gridInstrument(endpoint.String(), "DeleteFile", server.DeleteFileHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageCheckPartsRPC.Register(gm, gridInstrument(endpoint.String(), "CheckParts", server.CheckPartsHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageStatPartsRPC.Register(gm, gridInstrument(endpoint.String(), "StatParts", server.StatPartsHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageReadVersionRPC.Register(gm, gridInstrument(endpoint.String(), "ReadVersion", server.ReadVersionHandlerWS), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageReadVersionsRPC.RegisterNoInput(gm, server.ReadVersionsHandler, endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageWriteMetadataRPC.Register(gm, gridInstrument(endpoint.String(), "WriteMetadata", server.WriteMetadataHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageUpdateMetadataRPC.Register(gm, gridInstrument(endpoint.String(), "UpdateMetadata", server.UpdateMetadataHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageDeleteVersionRPC.Register(gm, gridInstrument(endpoint.String(), "DeleteVersion", server.DeleteVersionHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageReadXLRPC.Register(gm, gridInstrument(endpoint.String(), "ReadXL", server.ReadXLHandlerWS), endpoint.Path), "unable to register handler")
 			logger.FatalIf(storageNSScannerRPC.RegisterNoInput(gm, server.NSScannerHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageDiskInfoRPC.Register(gm, server.DiskInfoHandler, endpoint.Path), "unable to register handler")
-			logger.FatalIf(storageStatVolRPC.Register(gm, server.StatVolHandler, endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageDiskInfoRPC.Register(gm, gridInstrument(endpoint.String(), "DiskInfo", server.DiskInfoHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageDiskSnapshotRPC.Register(gm, gridInstrument(endpoint.String(), "DiskSnapshot", server.DiskSnapshotHandler), endpoint.Path), "unable to register handler")
+			logger.FatalIf(storageStatVolRPC.Register(gm, gridInstrument(endpoint.String(), "StatVol", server.StatVolHandler), endpoint.Path), "unable to register handler")
 			logger.FatalIf(gm.RegisterStreamingHandler(grid.HandlerWalkDir, grid.StreamHandler{
 				Subroute:    endpoint.Path,
 				Handle:      server.WalkDirHandler,
 				OutCapacity: 1,
 			}), "unable to register handler")
 
-			createStorage := func(endpoint Endpoint) bool {
-				xl, err := newXLStorage(endpoint, false)
-				if err != nil {
-					// if supported errors don't fail, we proceed to
-					// printing message and moving forward.
-					if errors.Is(err, errDriveIsRoot) {
-						err = fmt.Errorf("major: %v: minor: %v: %w", xl.major, xl.minor, err)
-					}
-					logFatalErrs(err, endpoint, false)
-					return false
-				}
-				storage := newXLStorageDiskIDCheck(xl, true)
-				storage.SetDiskID(xl.diskID)
-				// We do not have to do SetFormatData() since 'xl'
-				// already captures formatData cached.
-
-				globalLocalDrivesMu.Lock()
-				defer globalLocalDrivesMu.Unlock()
-
-				globalLocalDrivesMap[endpoint.String()] = storage
-				globalLocalSetDrives[endpoint.PoolIdx][endpoint.SetIdx][endpoint.DiskIdx] = storage
-				return true
-			}
-
 			if createStorage(endpoint) {
 				continue
 			}
@@ -20,15 +20,34 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
 	"reflect"
 	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/s2"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/grid"
+	xhttp "github.com/minio/minio/internal/http"
 	xnet "github.com/minio/pkg/v3/net"
+	"github.com/tinylib/msgp/msgp"
 )
 
 // Storage REST server, storageRESTReceiver and StorageRESTClient are
@@ -365,6 +384,74 @@ func newStorageRESTHTTPServerClient(t testing.TB) *storageRESTClient {
 	return restClient
 }
 
+func TestWeakETag(t *testing.T) {
+	fi := FileInfo{Size: 100, ModTime: UTCNow(), VersionID: mustGetUUID()}
+	etag := weakETag(&fi)
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected weak validator prefix, got %v", etag)
+	}
+	modified := fi
+	modified.ModTime = fi.ModTime.Add(time.Second)
+	if weakETag(&modified) == etag {
+		t.Fatalf("expected weak ETag to change when modtime changes")
+	}
+	sized := fi
+	sized.Size++
+	if weakETag(&sized) == etag {
+		t.Fatalf("expected weak ETag to change when size changes")
+	}
+}
+
+func testStorageAPIListOrphanedData(t *testing.T, storage *storageRESTClient) {
+	ctx := context.Background()
+	local := globalLocalSetDrives[0][0][0]
+
+	object := "my-object"
+	fi := FileInfo{
+		Name: object, Volume: "foo", VersionID: mustGetUUID(), ModTime: UTCNow(), DataDir: mustGetUUID(), Size: 10000,
+		Erasure: ErasureInfo{
+			Algorithm:    erasureAlgorithm,
+			DataBlocks:   4,
+			ParityBlocks: 4,
+			BlockSize:    blockSizeV2,
+			Index:        1,
+			Distribution: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+	}
+	if err := local.WriteMetadata(ctx, "", "foo", object, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// No orphans yet.
+	orphans, err := storage.ListOrphanedData(ctx, "foo", "")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(orphans) != 0 {
+		t.Fatalf("expected no orphans, got %v", orphans)
+	}
+
+	// Plant a data dir that no version references.
+	orphanDir := mustGetUUID()
+	if err := local.AppendFile(ctx, "foo", pathJoin(object, orphanDir, "part.1"), []byte("orphaned")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	orphans, err = storage.ListOrphanedData(ctx, "foo", "")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(orphans) != 1 {
+		t.Fatalf("expected 1 orphan, got %v", orphans)
+	}
+	if orphans[0].Path != pathJoin(object, orphanDir) {
+		t.Fatalf("expected orphan path %v, got %v", pathJoin(object, orphanDir), orphans[0].Path)
+	}
+	if orphans[0].ModTime.IsZero() {
+		t.Fatalf("expected orphan mod time to be set")
+	}
+}
+
 func TestStorageRESTClientDiskInfo(t *testing.T) {
 	restClient := newStorageRESTHTTPServerClient(t)
 
@@ -412,3 +499,3580 @@ func TestStorageRESTClientRenameFile(t *testing.T) {
 
 	testStorageAPIRenameFile(t, restClient)
 }
+
+func TestStorageRESTClientListOrphanedData(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+
+	testStorageAPIListOrphanedData(t, restClient)
+}
+
+func TestGetStorageViaEndpointBounds(t *testing.T) {
+	globalLocalDrivesMu.Lock()
+	prev := globalLocalSetDrives
+	globalLocalSetDrives = [][][]StorageAPI{{{nil}}}
+	globalLocalDrivesMu.Unlock()
+	defer func() {
+		globalLocalDrivesMu.Lock()
+		globalLocalSetDrives = prev
+		globalLocalDrivesMu.Unlock()
+	}()
+
+	for _, ep := range []Endpoint{
+		{PoolIdx: 1, SetIdx: 0, DiskIdx: 0},
+		{PoolIdx: -2, SetIdx: 0, DiskIdx: 0},
+		{PoolIdx: 0, SetIdx: 3, DiskIdx: 0},
+		{PoolIdx: 0, SetIdx: 0, DiskIdx: 42},
+		{PoolIdx: 0, SetIdx: 0, DiskIdx: -1},
+	} {
+		// Must not panic, out-of-range indices map to drive not found.
+		if storage := getStorageViaEndpoint(ep); storage != nil {
+			t.Fatalf("expected nil storage for %+v", ep)
+		}
+	}
+
+	if storage := getStorageViaEndpoint(Endpoint{PoolIdx: 0, SetIdx: 0, DiskIdx: 0}); storage != nil {
+		t.Fatalf("expected the planted nil drive, got %v", storage)
+	}
+}
+
+func TestStorageRESTClientDeleteVersionsSpill(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Large enough to exceed deleteVersionsWindowSize and trigger the
+	// spill-to-disk path on the server.
+	total := deleteVersionsWindowSize + 100
+	existing := map[int]struct{}{0: {}, 17: {}, total - 1: {}}
+
+	versions := make([]FileInfoVersions, total)
+	for i := range versions {
+		object := fmt.Sprintf("spill-object-%d", i)
+		fi := FileInfo{Name: object, Volume: "foo", VersionID: "", ModTime: UTCNow()}
+		if _, ok := existing[i]; ok {
+			wfi := newSwapTestFileInfo("foo", object, []byte("spill"))
+			if err := restClient.WriteMetadata(ctx, "", "foo", object, wfi); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+		}
+		versions[i] = FileInfoVersions{
+			Name:     object,
+			Volume:   "foo",
+			Versions: []FileInfo{fi},
+		}
+	}
+
+	errs := restClient.DeleteVersions(ctx, "foo", versions, DeleteOptions{})
+	if len(errs) != total {
+		t.Fatalf("expected %d results, got %d", total, len(errs))
+	}
+	for i, err := range errs {
+		if _, ok := existing[i]; ok {
+			if err != nil {
+				t.Fatalf("index %d: expected successful delete, got %v", i, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("index %d: expected error for non-existent object", i)
+		}
+	}
+	if _, err := restClient.ReadVersion(ctx, "", "foo", "spill-object-0", "", ReadOptions{}); err == nil {
+		t.Fatalf("expected spill-object-0 to be deleted")
+	}
+}
+
+func refreshDiskIDTestFormat(id string) []byte {
+	return []byte(`{"version":"1","format":"xl","id":"592a41c2-b7cc-4130-b883-c4b5cb15965b","xl":{"version":"3","this":"` + id + `","sets":[["` + id + `"]],"distributionAlgo":"CRCMOD"}}`)
+}
+
+func TestStorageRESTClientRefreshDiskID(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+	storage := globalLocalSetDrives[0][0][0]
+
+	idFirst, idSecond := mustGetUUID(), mustGetUUID()
+	if err := storage.WriteAll(ctx, minioMetaBucket, formatConfigFile, refreshDiskIDTestFormat(idFirst)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	id, err := restClient.RefreshDiskID(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if id != idFirst {
+		t.Fatalf("expected %s, got %s", idFirst, id)
+	}
+
+	// Re-format the drive in place, the cached id is now stale.
+	if err = storage.WriteAll(ctx, minioMetaBucket, formatConfigFile, refreshDiskIDTestFormat(idSecond)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if id, err = storage.GetDiskID(); err != nil || id != idFirst {
+		t.Fatalf("expected the stale cached id %s, got %s (%v)", idFirst, id, err)
+	}
+
+	if id, err = restClient.RefreshDiskID(ctx); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if id != idSecond {
+		t.Fatalf("expected %s, got %s", idSecond, id)
+	}
+	if id, err = storage.GetDiskID(); err != nil || id != idSecond {
+		t.Fatalf("expected the refreshed id %s, got %s (%v)", idSecond, id, err)
+	}
+}
+
+func TestStorageRESTClientReadFileStream(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	payload := []byte("streamed contents with trailing status marker")
+	if err := restClient.AppendFile(ctx, "foo", "stream-object", payload); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	rc, err := restClient.ReadFileStream(ctx, "foo", "stream-object", 0, int64(len(payload)))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer rc.Close()
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("expected %q, got %q", payload, got)
+	}
+}
+
+func TestTrailingStatusReader(t *testing.T) {
+	body := []byte("some streamed bytes")
+	for _, test := range []struct {
+		name    string
+		stream  []byte
+		wantErr error
+	}{
+		{name: "complete", stream: append(append([]byte{}, body...), 0), wantErr: io.EOF},
+		{name: "read-error-partway", stream: append(append([]byte{}, body...), 1), wantErr: io.ErrUnexpectedEOF},
+		{name: "no-marker", stream: nil, wantErr: io.ErrUnexpectedEOF},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r := &trailingStatusReader{rc: io.NopCloser(bytes.NewReader(test.stream))}
+			got, err := io.ReadAll(r)
+			if test.wantErr == io.EOF {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				if !bytes.Equal(got, body) {
+					t.Fatalf("expected %q, got %q", body, got)
+				}
+				return
+			}
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStorageRESTClientGridHandlers(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+
+	handlers, err := restClient.GridHandlers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	for _, want := range []string{"ListDir", "WalkDir", "WriteMetadata", "ReadVersion"} {
+		var found bool
+		for _, line := range handlers {
+			if line == want || strings.HasPrefix(line, want+" ") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("expected handler %q to be registered, got %v", want, handlers)
+		}
+	}
+}
+
+func TestStorageRESTClientReadRecords(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	if err := restClient.AppendFile(ctx, "foo", "records", []byte("first,second,,fourth")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	records, err := restClient.ReadRecords(ctx, "foo", "records", ',')
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	want := []string{"first", "second", "", "fourth"}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d records, got %d", len(want), len(records))
+	}
+	for i := range want {
+		if string(records[i]) != want[i] {
+			t.Fatalf("record %d: expected %q, got %q", i, want[i], records[i])
+		}
+	}
+
+	if _, err = restClient.ReadRecords(ctx, "foo", "does-not-exist", ','); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+}
+
+func TestStorageRESTClientBitrotErrors(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	storage := globalLocalSetDrives[0][0][0]
+
+	infos, err := restClient.BitrotErrors(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(infos) != 0 {
+		t.Fatalf("expected no detections on a fresh drive, got %v", infos)
+	}
+
+	// Plant a part whose stored checksum cannot match its contents.
+	dataDir := mustGetUUID()
+	data := []byte("irrelevant, the checksum below is bogus")
+	if err = storage.AppendFile(ctx, "foo", pathJoin("bitrot-obj", dataDir, "part.1"), data); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	fi := FileInfo{
+		Volume: "foo", Name: "bitrot-obj", DataDir: dataDir, ModTime: UTCNow(), Size: int64(len(data)),
+		Erasure: ErasureInfo{
+			Algorithm:    erasureAlgorithm,
+			DataBlocks:   4,
+			ParityBlocks: 4,
+			BlockSize:    blockSizeV2,
+			Index:        1,
+			Distribution: []int{0, 1, 2, 3, 4, 5, 6, 7},
+			Checksums:    []ChecksumInfo{{PartNumber: 1, Algorithm: SHA256, Hash: make([]byte, 32)}},
+		},
+		Parts: []ObjectPartInfo{{Number: 1, Size: int64(len(data)), ActualSize: int64(len(data))}},
+	}
+	resp, err := storage.VerifyFile(ctx, "foo", "bitrot-obj", fi)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if resp.Results[0] != checkPartFileCorrupt {
+		t.Fatalf("expected corrupt part, got %d", resp.Results[0])
+	}
+
+	infos, err = restClient.BitrotErrors(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(infos) != 1 {
+		t.Fatalf("expected one detection, got %v", infos)
+	}
+	if infos[0].Volume != "foo" || infos[0].Path != "bitrot-obj" || infos[0].Part != 1 || infos[0].Detected.IsZero() {
+		t.Fatalf("unexpected detection %+v", infos[0])
+	}
+}
+
+func newSwapTestFileInfo(volume, name string, data []byte) FileInfo {
+	fi := FileInfo{
+		Volume: volume, Name: name, ModTime: UTCNow(), Size: int64(len(data)), Data: data,
+		Erasure: ErasureInfo{
+			Algorithm:    erasureAlgorithm,
+			DataBlocks:   4,
+			ParityBlocks: 4,
+			BlockSize:    blockSizeV2,
+			Index:        1,
+			Distribution: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+		Parts: []ObjectPartInfo{{Number: 1, Size: int64(len(data)), ActualSize: int64(len(data))}},
+	}
+	fi.SetInlineData()
+	return fi
+}
+
+func TestStorageRESTClientSwapData(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	dataA, dataB := []byte("contents-of-object-a"), []byte("contents-of-b")
+	fiA := newSwapTestFileInfo("foo", "swap-a", dataA)
+	fiB := newSwapTestFileInfo("foo", "swap-b", dataB)
+	for _, fi := range []FileInfo{fiA, fiB} {
+		if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if err := restClient.SwapData(ctx, "foo", "swap-a", "swap-b"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	gotA, err := restClient.ReadVersion(ctx, "", "foo", "swap-a", "", ReadOptions{ReadData: true})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	gotB, err := restClient.ReadVersion(ctx, "", "foo", "swap-b", "", ReadOptions{ReadData: true})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if gotA.Name != "swap-a" || !gotA.ModTime.Equal(fiA.ModTime) {
+		t.Fatalf("swap-a lost its identity: %v", gotA)
+	}
+	if !bytes.Equal(gotA.Data, dataB) || gotA.Size != int64(len(dataB)) {
+		t.Fatalf("swap-a does not carry swap-b's contents: %q", gotA.Data)
+	}
+	if gotB.Name != "swap-b" || !gotB.ModTime.Equal(fiB.ModTime) {
+		t.Fatalf("swap-b lost its identity: %v", gotB)
+	}
+	if !bytes.Equal(gotB.Data, dataA) || gotB.Size != int64(len(dataA)) {
+		t.Fatalf("swap-b does not carry swap-a's contents: %q", gotB.Data)
+	}
+}
+
+func TestSwapObjectDataRollback(t *testing.T) {
+	storage, _, err := newXLStorageTestSetup(t)
+	if err != nil {
+		t.Fatalf("Unable to create xlStorage test setup, %s", err)
+	}
+	ctx := context.Background()
+
+	volume := "swap-vol"
+	if err = storage.MakeVol(ctx, volume); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	dataA, dataB := []byte("contents-of-object-a"), []byte("contents-of-b")
+	fiA := newSwapTestFileInfo(volume, "swap-a", dataA)
+	fiB := newSwapTestFileInfo(volume, "swap-b", dataB)
+	for _, fi := range []FileInfo{fiA, fiB} {
+		if err = storage.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	// Fail the second metadata write (calls: 2x ReadVersion, 2x
+	// WriteMetadata), the first object must be restored.
+	naughty := newNaughtyDisk(storage, map[int]error{4: errFaultyDisk}, nil)
+	if err = swapObjectData(ctx, naughty, volume, "swap-a", "swap-b"); err != errFaultyDisk {
+		t.Fatalf("expected %v, got %v", errFaultyDisk, err)
+	}
+
+	for _, want := range []struct {
+		name string
+		data []byte
+	}{{"swap-a", dataA}, {"swap-b", dataB}} {
+		got, err := storage.ReadVersion(ctx, "", volume, want.name, "", ReadOptions{ReadData: true})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if !bytes.Equal(got.Data, want.data) {
+			t.Fatalf("%s not rolled back, got %q", want.name, got.Data)
+		}
+	}
+}
+
+func TestStorageRESTClientReadSession(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	payload := make([]byte, 4096)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+	if err := restClient.AppendFile(ctx, "foo", "session-object", payload); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	id, err := restClient.OpenReadSession(ctx, "foo", "session-object", 0, int64(len(payload)))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer restClient.CloseReadSession(ctx, id)
+
+	buf := make([]byte, 128)
+	for off := int64(0); off < int64(len(payload)); off += int64(len(buf)) {
+		n, err := restClient.ReadSession(ctx, id, off, buf)
+		if err != nil {
+			t.Fatalf("offset %d: unexpected error %v", off, err)
+		}
+		if n != int64(len(buf)) || !bytes.Equal(buf, payload[off:off+n]) {
+			t.Fatalf("offset %d: unexpected data returned", off)
+		}
+	}
+
+	// Unknown session must be rejected.
+	if _, err = restClient.ReadSession(ctx, "unknown-session", 0, buf); err == nil {
+		t.Fatalf("expected error for unknown session")
+	}
+}
+
+func benchmarkSmallReads(b *testing.B, read func(ctx context.Context, offset int64, buf []byte) error) {
+	ctx := context.Background()
+	buf := make([]byte, 512)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := read(ctx, int64(i%8)*int64(len(buf)), buf); err != nil {
+			b.Fatalf("unexpected error %v", err)
+		}
+	}
+}
+
+func BenchmarkStorageRESTClientSmallReads(b *testing.B) {
+	restClient := newStorageRESTHTTPServerClient(b)
+	ctx := context.Background()
+
+	payload := make([]byte, 8*512)
+	if err := restClient.AppendFile(ctx, "foo", "bench-object", payload); err != nil {
+		b.Fatalf("unexpected error %v", err)
+	}
+
+	b.Run("direct", func(b *testing.B) {
+		benchmarkSmallReads(b, func(ctx context.Context, offset int64, buf []byte) error {
+			_, err := restClient.ReadFile(ctx, "foo", "bench-object", offset, buf, nil)
+			return err
+		})
+	})
+	b.Run("session", func(b *testing.B) {
+		id, err := restClient.OpenReadSession(ctx, "foo", "bench-object", 0, int64(len(payload)))
+		if err != nil {
+			b.Fatalf("unexpected error %v", err)
+		}
+		defer restClient.CloseReadSession(ctx, id)
+		benchmarkSmallReads(b, func(ctx context.Context, offset int64, buf []byte) error {
+			_, err := restClient.ReadSession(ctx, id, offset, buf)
+			return err
+		})
+	})
+}
+
+func TestStorageRESTClientObjectTimestamps(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Write three versions with distinct modtimes, newest last.
+	base := UTCNow().Add(-time.Hour)
+	modTimes := []time.Time{base, base.Add(10 * time.Minute), base.Add(30 * time.Minute)}
+	for _, modTime := range modTimes {
+		fi := newSwapTestFileInfo("foo", "ts-obj", []byte("payload"))
+		fi.VersionID = mustGetUUID()
+		fi.ModTime = modTime
+		if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	ts, err := restClient.ObjectTimestamps(ctx, "foo", "ts-obj")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !ts.Created.Equal(modTimes[0]) {
+		t.Errorf("expected creation time %v, got %v", modTimes[0], ts.Created)
+	}
+	if !ts.Modified.Equal(modTimes[2]) {
+		t.Errorf("expected modification time %v, got %v", modTimes[2], ts.Modified)
+	}
+
+	if _, err = restClient.ObjectTimestamps(ctx, "foo", "ts-missing"); err != errFileNotFound {
+		t.Errorf("expected errFileNotFound, got %v", err)
+	}
+}
+
+func TestStorageRESTClientMerkleTree(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Four bytes per leaf gives three full leaves and a short tail.
+	const leafSize = 4
+	content := []byte("aaaabbbbccccdd")
+	if err := restClient.WriteAll(ctx, "foo", "mtree-obj", content); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	mt, err := restClient.MerkleTree(ctx, "foo", "mtree-obj", leafSize, false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if mt.LeafSize != leafSize {
+		t.Fatalf("expected leaf size %d, got %d", leafSize, mt.LeafSize)
+	}
+	if len(mt.Leaves) != 4 {
+		t.Fatalf("expected 4 leaves, got %d", len(mt.Leaves))
+	}
+	if len(mt.Root) == 0 {
+		t.Fatal("expected a non-empty root")
+	}
+
+	// The root must be stable across recomputations.
+	again, err := restClient.MerkleTree(ctx, "foo", "mtree-obj", leafSize, false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(mt.Root, again.Root) {
+		t.Fatal("expected stable root across recomputations")
+	}
+
+	// Flipping a byte in the third leaf must change that leaf hash
+	// and the root, but leave the other leaf hashes intact.
+	changed := append([]byte{}, content...)
+	changed[9] = 'X'
+	if err = restClient.WriteAll(ctx, "foo", "mtree-obj", changed); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	after, err := restClient.MerkleTree(ctx, "foo", "mtree-obj", leafSize, false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if bytes.Equal(mt.Root, after.Root) {
+		t.Fatal("expected root to change when a leaf changes")
+	}
+	for i := range mt.Leaves {
+		if changedLeaf := !bytes.Equal(mt.Leaves[i], after.Leaves[i]); changedLeaf != (i == 2) {
+			t.Errorf("leaf %d: changed=%v, expected change only in leaf 2", i, changedLeaf)
+		}
+	}
+
+	// A cached tree must be reused as long as the leaf size matches.
+	cached, err := restClient.MerkleTree(ctx, "foo", "mtree-obj", leafSize, true)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(cached.Root, after.Root) {
+		t.Fatal("expected cached root to match computed root")
+	}
+	if _, err = restClient.MerkleTree(ctx, "foo", "mtree-obj", leafSize, true); err != nil {
+		t.Fatalf("unexpected error reading cached tree %v", err)
+	}
+}
+
+func TestStorageRESTClientDriveInitState(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	st, err := restClient.DriveInitState(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if st.State != driveInitReady {
+		t.Fatalf("expected %q, got %+v", driveInitReady, st)
+	}
+	if st.LastErr != "" {
+		t.Fatalf("expected no error for a ready drive, got %q", st.LastErr)
+	}
+
+	// A drive whose path cannot even be stat'ed never comes up;
+	// createStorage must keep retrying and report why.
+	tg, err := grid.SetupTestGrid(2)
+	if err != nil {
+		t.Fatalf("SetupTestGrid: %v", err)
+	}
+	t.Cleanup(tg.Cleanup)
+	prevHost, prevPort := globalMinioHost, globalMinioPort
+	t.Cleanup(func() {
+		globalMinioHost, globalMinioPort = prevHost, prevPort
+	})
+
+	url, err := xnet.ParseHTTPURL(tg.Servers[1].URL)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// A single path component beyond the name limit fails Lstat in
+	// getValidPath with a non-retryable OS error.
+	url.Path = pathJoin(t.TempDir(), strings.Repeat("a", 300))
+
+	globalMinioHost, globalMinioPort = mustSplitHostPort(url.Host)
+
+	endpoint, err := NewEndpoint(url.String())
+	if err != nil {
+		t.Fatalf("NewEndpoint failed %v", endpoint)
+	}
+	if err = endpoint.UpdateIsLocal(); err != nil {
+		t.Fatalf("UpdateIsLocal failed %v", err)
+	}
+	endpoint.PoolIdx = 0
+	endpoint.SetIdx = 0
+	endpoint.DiskIdx = 0
+
+	poolEps := []PoolEndpoints{{
+		Endpoints: Endpoints{endpoint},
+	}}
+	poolEps[0].SetCount = 1
+	poolEps[0].DrivesPerSet = 1
+
+	registerStorageRESTHandlers(tg.Mux[0], poolEps, tg.Managers[0])
+	registerStorageRESTHandlers(tg.Mux[1], poolEps, tg.Managers[1])
+
+	badClient, err := newStorageRESTClient(endpoint, false, tg.Managers[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, err = badClient.DriveInitState(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if st.State != driveInitRetrying {
+		t.Fatalf("expected %q, got %+v", driveInitRetrying, st)
+	}
+	if st.LastErr == "" {
+		t.Fatal("expected the last initialization error to be reported")
+	}
+}
+
+// convertBitrotDisk serves a canned version to convertBitrotAlgorithm
+// and records the metadata written back, if any. Only the two methods
+// the conversion touches are implemented.
+type convertBitrotDisk struct {
+	StorageAPI
+	fi      FileInfo
+	written *FileInfo
+}
+
+func (d *convertBitrotDisk) ReadVersion(ctx context.Context, origvolume, volume, path, versionID string, opts ReadOptions) (FileInfo, error) {
+	return d.fi, nil
+}
+
+func (d *convertBitrotDisk) WriteMetadata(ctx context.Context, origvolume, volume, path string, fi FileInfo) error {
+	d.written = &fi
+	return nil
+}
+
+func TestConvertBitrotAlgorithm(t *testing.T) {
+	ctx := context.Background()
+
+	data := []byte("bitrot-convert-payload")
+	sum := SHA256.New()
+	sum.Write(data)
+
+	fi := newSwapTestFileInfo("foo", "convert-obj", data)
+	fi.Erasure.Checksums = []ChecksumInfo{{PartNumber: 1, Algorithm: SHA256, Hash: sum.Sum(nil)}}
+
+	disk := &convertBitrotDisk{fi: fi}
+	if err := convertBitrotAlgorithm(ctx, disk, "foo", "convert-obj", "", HighwayHash256); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if disk.written == nil {
+		t.Fatal("expected the converted checksums to be written back")
+	}
+	got := disk.written.Erasure.GetChecksumInfo(1)
+	if got.Algorithm != HighwayHash256 {
+		t.Fatalf("expected checksum algorithm %v, got %v", HighwayHash256, got.Algorithm)
+	}
+	want := HighwayHash256.New()
+	want.Write(data)
+	if !bytes.Equal(got.Hash, want.Sum(nil)) {
+		t.Fatal("expected checksum to match the recomputed hash")
+	}
+
+	// Converting to the algorithm already in place is a no-op.
+	disk = &convertBitrotDisk{fi: *disk.written}
+	if err := convertBitrotAlgorithm(ctx, disk, "foo", "convert-obj", "", HighwayHash256); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if disk.written != nil {
+		t.Fatal("expected no metadata write for an already-converted object")
+	}
+
+	// A part failing its current checksum must refuse conversion and
+	// leave the metadata untouched.
+	bad := newSwapTestFileInfo("foo", "convert-bad", data)
+	bad.Erasure.Checksums = []ChecksumInfo{{PartNumber: 1, Algorithm: SHA256, Hash: make([]byte, 32)}}
+	disk = &convertBitrotDisk{fi: bad}
+	if err := convertBitrotAlgorithm(ctx, disk, "foo", "convert-bad", "", HighwayHash256); err != errFileCorrupt {
+		t.Fatalf("expected errFileCorrupt, got %v", err)
+	}
+	if disk.written != nil {
+		t.Fatal("expected no metadata write after refusal")
+	}
+}
+
+func TestStorageRESTClientConvertBitrot(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Objects written with streaming bitrot carry no whole-part
+	// checksums, so there is nothing the conversion could migrate.
+	fi := newSwapTestFileInfo("foo", "convert-obj", []byte("bitrot-convert-payload"))
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := restClient.ConvertBitrot(ctx, "foo", "convert-obj", "", HighwayHash256); err != errInvalidArgument {
+		t.Fatalf("expected errInvalidArgument, got %v", err)
+	}
+}
+
+func TestStorageRESTClientListIncompleteUploads(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	storage := globalLocalSetDrives[0][0][0]
+	if err := storage.MakeVol(ctx, minioMetaMultipartBucket); err != nil && err != errVolumeExists {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// Plant an incomplete upload with two parts in the staging area.
+	initiated := UTCNow().Add(-time.Minute).Truncate(time.Millisecond)
+	shaDir := getSHA256Hash([]byte(pathJoin("foo", "mp-obj")))
+	uploadID := fmt.Sprintf("%sx%d", mustGetUUID(), initiated.UnixNano())
+	uploadIDPath := pathJoin(shaDir, uploadID)
+	dataDir := mustGetUUID()
+
+	fi := FileInfo{
+		Volume: minioMetaMultipartBucket, Name: uploadIDPath, DataDir: dataDir, ModTime: initiated,
+		Erasure: ErasureInfo{
+			Algorithm:    erasureAlgorithm,
+			DataBlocks:   4,
+			ParityBlocks: 4,
+			BlockSize:    blockSizeV2,
+			Index:        1,
+			Distribution: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+	}
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	for part := 1; part <= 2; part++ {
+		partPath := pathJoin(uploadIDPath, dataDir, fmt.Sprintf("part.%d", part))
+		if err := restClient.WriteAll(ctx, minioMetaMultipartBucket, partPath, []byte("part-data")); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if err := restClient.WriteAll(ctx, minioMetaMultipartBucket, partPath+".meta", []byte("part-meta")); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	uploads, err := restClient.ListIncompleteUploads(ctx, "foo", "mp-obj")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(uploads) != 1 {
+		t.Fatalf("expected 1 upload, got %d", len(uploads))
+	}
+	got := uploads[0]
+	if got.UploadID != uploadID || got.ShaDir != shaDir {
+		t.Fatalf("unexpected upload %+v", got)
+	}
+	if got.Parts != 2 {
+		t.Fatalf("expected 2 parts, got %d", got.Parts)
+	}
+	if !got.Initiated.Equal(initiated) {
+		t.Fatalf("expected initiated %v, got %v", initiated, got.Initiated)
+	}
+
+	// Filtering on another object must not return this upload.
+	uploads, err = restClient.ListIncompleteUploads(ctx, "foo", "other-obj")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(uploads) != 0 {
+		t.Fatalf("expected no uploads, got %d", len(uploads))
+	}
+}
+
+// writeStreamingBitrotFile formats data as interleaved
+// hash-then-payload blocks, the on-disk layout of streaming bitrot.
+func writeStreamingBitrotFile(data []byte, algo BitrotAlgorithm, shardSize int) []byte {
+	h := algo.New()
+	var out []byte
+	for len(data) > 0 {
+		n := min(shardSize, len(data))
+		h.Reset()
+		h.Write(data[:n])
+		out = append(out, h.Sum(nil)...)
+		out = append(out, data[:n]...)
+		data = data[n:]
+	}
+	return out
+}
+
+func TestStorageRESTClientReadFileVerified(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	const shardSize = 8
+	data := []byte("0123456789abcdefXYZ")
+	formatted := writeStreamingBitrotFile(data, HighwayHash256, shardSize)
+	if err := restClient.WriteAll(ctx, "foo", "verified-obj", formatted); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	buf := make([]byte, len(data))
+	n, err := restClient.ReadFileVerified(ctx, "foo", "verified-obj", 0, buf, HighwayHash256, shardSize)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if n != int64(len(data)) || !bytes.Equal(buf, data) {
+		t.Fatalf("expected %q, got %q", data, buf[:n])
+	}
+
+	// Reads spanning block boundaries at an offset must also verify.
+	buf = make([]byte, 6)
+	if _, err = restClient.ReadFileVerified(ctx, "foo", "verified-obj", 5, buf, HighwayHash256, shardSize); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(buf, data[5:11]) {
+		t.Fatalf("expected %q, got %q", data[5:11], buf)
+	}
+
+	// Corrupt a byte in the second block's payload, any read touching
+	// it must fail without serving bytes.
+	hashSize := HighwayHash256.New().Size()
+	corrupted := append([]byte{}, formatted...)
+	corrupted[2*hashSize+shardSize+3] ^= 0xff
+	if err = restClient.WriteAll(ctx, "foo", "verified-bad", corrupted); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	buf = make([]byte, len(data))
+	if _, err = restClient.ReadFileVerified(ctx, "foo", "verified-bad", 0, buf, HighwayHash256, shardSize); err != errCorruptedData {
+		t.Fatalf("expected errCorruptedData, got %v", err)
+	}
+
+	// The first (clean) block is still readable on its own.
+	buf = make([]byte, shardSize)
+	if _, err = restClient.ReadFileVerified(ctx, "foo", "verified-bad", 0, buf, HighwayHash256, shardSize); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(buf, data[:shardSize]) {
+		t.Fatalf("expected %q, got %q", data[:shardSize], buf)
+	}
+}
+
+func TestStorageRESTClientScanBudget(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	limit, err := restClient.GetScanBudget(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if limit != 0 {
+		t.Fatalf("expected unlimited budget by default, got %d", limit)
+	}
+
+	if err = restClient.SetScanBudget(ctx, 5); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if limit, err = restClient.GetScanBudget(ctx); err != nil || limit != 5 {
+		t.Fatalf("expected budget 5, got %d (%v)", limit, err)
+	}
+
+	// Mock scan: a cycle must stop consuming once the budget is hit
+	// and start over after a reset.
+	budget := scanBudgetFor(restClient.endpoint.String())
+	budget.reset()
+	scanned := 0
+	for i := 0; i < 20; i++ {
+		if budget.consume() {
+			scanned++
+		}
+	}
+	if scanned != 5 {
+		t.Fatalf("expected 5 scanned objects, got %d", scanned)
+	}
+	budget.reset()
+	if !budget.consume() {
+		t.Fatal("expected budget to be available after reset")
+	}
+
+	// Removing the cap makes the budget unlimited again.
+	if err = restClient.SetScanBudget(ctx, 0); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	budget.reset()
+	for i := 0; i < 20; i++ {
+		if !budget.consume() {
+			t.Fatal("expected unlimited budget to never run out")
+		}
+	}
+}
+
+func TestStorageRESTClientReadAllEncoded(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	data := []byte("encoded-fixture")
+	if err := restClient.WriteAll(ctx, "foo", "encoded-obj", data); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	raw, err := restClient.ReadAllEncoded(ctx, "foo", "encoded-obj", "")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(raw, data) {
+		t.Fatalf("expected %q, got %q", data, raw)
+	}
+
+	b64, err := restClient.ReadAllEncoded(ctx, "foo", "encoded-obj", "base64")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if want := base64.StdEncoding.EncodeToString(data); string(b64) != want {
+		t.Fatalf("expected %q, got %q", want, b64)
+	}
+
+	hexed, err := restClient.ReadAllEncoded(ctx, "foo", "encoded-obj", "hex")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if want := hex.EncodeToString(data); string(hexed) != want {
+		t.Fatalf("expected %q, got %q", want, hexed)
+	}
+
+	if _, err = restClient.ReadAllEncoded(ctx, "foo", "encoded-obj", "rot13"); err == nil {
+		t.Fatal("expected an error for an unsupported encoding")
+	}
+}
+
+func TestStorageRESTClientMutateMetadata(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	fi := newSwapTestFileInfo("foo", "mutate-obj", []byte("mutate-data"))
+	fi.Metadata = map[string]string{"x-amz-meta-keep": "yes", "x-amz-meta-drop": "old"}
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	metadata, err := restClient.MutateMetadata(ctx, "foo", "mutate-obj", "", []MetaMutationOp{
+		{Op: metaMutationSet, Key: "x-amz-meta-added", Value: "new"},
+		{Op: metaMutationDelete, Key: "x-amz-meta-drop"},
+		{Op: metaMutationSet, Key: "x-amz-meta-keep", Value: "updated"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	want := map[string]string{"x-amz-meta-keep": "updated", "x-amz-meta-added": "new"}
+	for k, v := range want {
+		if metadata[k] != v {
+			t.Fatalf("expected %s=%q, got %q", k, v, metadata[k])
+		}
+	}
+	if _, ok := metadata["x-amz-meta-drop"]; ok {
+		t.Fatal("expected deleted key to be gone")
+	}
+
+	got, err := restClient.ReadVersion(ctx, "", "foo", "mutate-obj", "", ReadOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	for k, v := range want {
+		if got.Metadata[k] != v {
+			t.Fatalf("expected persisted %s=%q, got %q", k, v, got.Metadata[k])
+		}
+	}
+
+	// One invalid op must reject the whole transaction, leaving the
+	// valid ops unapplied.
+	_, err = restClient.MutateMetadata(ctx, "foo", "mutate-obj", "", []MetaMutationOp{
+		{Op: metaMutationSet, Key: "x-amz-meta-partial", Value: "nope"},
+		{Op: "rename", Key: "x-amz-meta-keep", Value: "other"},
+	})
+	if err != errInvalidArgument {
+		t.Fatalf("expected errInvalidArgument, got %v", err)
+	}
+	got, err = restClient.ReadVersion(ctx, "", "foo", "mutate-obj", "", ReadOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, ok := got.Metadata["x-amz-meta-partial"]; ok {
+		t.Fatal("expected no partial apply after a rejected transaction")
+	}
+	if got.Metadata["x-amz-meta-keep"] != "updated" {
+		t.Fatalf("expected metadata to be untouched, got %q", got.Metadata["x-amz-meta-keep"])
+	}
+}
+
+func TestStorageRESTClientTraceSampling(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+
+	// Default rate traces everything.
+	rate, err := restClient.GetTraceRate(context.Background())
+	if err != nil {
+		t.Fatalf("GetTraceRate failed, %v", err)
+	}
+	if rate != 100 {
+		t.Fatalf("expected default rate 100, got %d", rate)
+	}
+
+	// Out of range rates are rejected.
+	if err = restClient.SetTraceRate(context.Background(), 150); !errors.Is(err, errInvalidArgument) {
+		t.Fatalf("expected errInvalidArgument for rate 150, got %v", err)
+	}
+
+	if err = restClient.SetTraceRate(context.Background(), 30); err != nil {
+		t.Fatalf("SetTraceRate failed, %v", err)
+	}
+	rate, err = restClient.GetTraceRate(context.Background())
+	if err != nil {
+		t.Fatalf("GetTraceRate failed, %v", err)
+	}
+	if rate != 30 {
+		t.Fatalf("expected rate 30, got %d", rate)
+	}
+
+	sampler := traceSamplerFor(restClient.endpoint.String())
+	const iterations = 10000
+	sampled := 0
+	for range iterations {
+		if sampler.sample() {
+			sampled++
+		}
+	}
+	// Roughly 30% of requests should be sampled.
+	if sampled < iterations*20/100 || sampled > iterations*40/100 {
+		t.Fatalf("expected roughly 30%% sampled, got %d of %d", sampled, iterations)
+	}
+
+	if err = restClient.SetTraceRate(context.Background(), 0); err != nil {
+		t.Fatalf("SetTraceRate failed, %v", err)
+	}
+	for range iterations {
+		if sampler.sample() {
+			t.Fatal("sampled a request at rate 0")
+		}
+	}
+
+	if err = restClient.SetTraceRate(context.Background(), 100); err != nil {
+		t.Fatalf("SetTraceRate failed, %v", err)
+	}
+	for range iterations {
+		if !sampler.sample() {
+			t.Fatal("skipped a request at rate 100")
+		}
+	}
+}
+
+func TestStorageRESTClientErasureSetLayout(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+	local := globalLocalSetDrives[0][0][0]
+
+	// Unformatted drive has no format.json yet.
+	if _, err := restClient.ErasureSetLayout(ctx); !errors.Is(err, errFileNotFound) && !errors.Is(err, errVolumeNotFound) {
+		t.Fatalf("expected missing format error, got %v", err)
+	}
+
+	// Format the drive into set 1, disk 0 of a 2x2 layout.
+	format := newFormatErasureV3(2, 2)
+	format.Erasure.This = format.Erasure.Sets[1][0]
+	buf, err := json.Marshal(format)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = local.MakeVol(ctx, minioMetaBucket); err != nil && !errors.Is(err, errVolumeExists) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = local.WriteAll(ctx, minioMetaBucket, formatConfigFile, buf); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	layout, err := restClient.ErasureSetLayout(ctx)
+	if err != nil {
+		t.Fatalf("ErasureSetLayout failed, %v", err)
+	}
+	if layout.PoolIdx != restClient.endpoint.PoolIdx || layout.SetIdx != restClient.endpoint.SetIdx || layout.DiskIdx != restClient.endpoint.DiskIdx {
+		t.Fatalf("expected configured indices %d/%d/%d, got %d/%d/%d",
+			restClient.endpoint.PoolIdx, restClient.endpoint.SetIdx, restClient.endpoint.DiskIdx,
+			layout.PoolIdx, layout.SetIdx, layout.DiskIdx)
+	}
+	if layout.FormatSetIdx != 1 || layout.FormatDiskIdx != 0 {
+		t.Fatalf("expected format placement 1/0, got %d/%d", layout.FormatSetIdx, layout.FormatDiskIdx)
+	}
+	if layout.SetCount != 2 || layout.SetDriveCount != 2 {
+		t.Fatalf("expected 2x2 layout, got %dx%d", layout.SetCount, layout.SetDriveCount)
+	}
+	if layout.DiskID != format.Erasure.This {
+		t.Fatalf("expected disk id %v, got %v", format.Erasure.This, layout.DiskID)
+	}
+}
+
+func TestStorageRESTClientIsLatestVersion(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Write two versions, newest last.
+	base := UTCNow().Add(-time.Hour)
+	var versionIDs []string
+	for i, modTime := range []time.Time{base, base.Add(10 * time.Minute)} {
+		fi := newSwapTestFileInfo("foo", "latest-obj", []byte(fmt.Sprintf("payload-%d", i)))
+		fi.VersionID = mustGetUUID()
+		fi.ModTime = modTime
+		if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		versionIDs = append(versionIDs, fi.VersionID)
+	}
+
+	latest, err := restClient.IsLatestVersion(ctx, "foo", "latest-obj", versionIDs[1])
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !latest {
+		t.Errorf("expected version %v to be latest", versionIDs[1])
+	}
+
+	latest, err = restClient.IsLatestVersion(ctx, "foo", "latest-obj", versionIDs[0])
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if latest {
+		t.Errorf("expected version %v to not be latest", versionIDs[0])
+	}
+
+	if _, err = restClient.IsLatestVersion(ctx, "foo", "latest-obj", mustGetUUID()); err != errFileVersionNotFound {
+		t.Errorf("expected errFileVersionNotFound, got %v", err)
+	}
+
+	if _, err = restClient.IsLatestVersion(ctx, "foo", "latest-missing", mustGetUUID()); err != errFileNotFound {
+		t.Errorf("expected errFileNotFound, got %v", err)
+	}
+}
+
+func TestStorageRESTClientSoftDeleteRestore(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+	t.Cleanup(func() { setSoftDeleteWindow(restClient.endpoint.String(), 0) })
+
+	if err := restClient.SetSoftDeleteWindow(ctx, time.Hour); err != nil {
+		t.Fatalf("SetSoftDeleteWindow failed, %v", err)
+	}
+
+	fi := newSwapTestFileInfo("foo", "trash-obj", []byte("precious"))
+	fi.VersionID = mustGetUUID()
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := restClient.DeleteVersion(ctx, "foo", "trash-obj", fi, false, DeleteOptions{}); err != nil {
+		t.Fatalf("DeleteVersion failed, %v", err)
+	}
+	if _, err := restClient.ReadVersion(ctx, "", "foo", "trash-obj", fi.VersionID, ReadOptions{ReadData: true}); err == nil {
+		t.Fatal("expected version to be gone after soft delete")
+	}
+
+	if err := restClient.RestoreVersion(ctx, "foo", "trash-obj", fi.VersionID); err != nil {
+		t.Fatalf("RestoreVersion failed, %v", err)
+	}
+	rfi, err := restClient.ReadVersion(ctx, "", "foo", "trash-obj", fi.VersionID, ReadOptions{ReadData: true})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(rfi.Data, []byte("precious")) {
+		t.Fatalf("expected restored data %q, got %q", "precious", rfi.Data)
+	}
+	for _, key := range []string{trashExpiryMetaKey, trashOrigVolumeMetaKey, trashOrigPathMetaKey} {
+		if _, ok := rfi.Metadata[key]; ok {
+			t.Errorf("expected %v to be cleared on restore", key)
+		}
+	}
+
+	// Restoring twice must fail, the trash entry is gone.
+	if err = restClient.RestoreVersion(ctx, "foo", "trash-obj", fi.VersionID); err == nil {
+		t.Fatal("expected second restore to fail")
+	}
+}
+
+func TestStorageRESTClientSoftDeleteExpiry(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+	local := globalLocalSetDrives[0][0][0]
+	t.Cleanup(func() { setSoftDeleteWindow(restClient.endpoint.String(), 0) })
+
+	if err := restClient.SetSoftDeleteWindow(ctx, time.Millisecond); err != nil {
+		t.Fatalf("SetSoftDeleteWindow failed, %v", err)
+	}
+
+	fi := newSwapTestFileInfo("foo", "expired-obj", []byte("expendable"))
+	fi.VersionID = mustGetUUID()
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := restClient.DeleteVersion(ctx, "foo", "expired-obj", fi, false, DeleteOptions{}); err != nil {
+		t.Fatalf("DeleteVersion failed, %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if err := reapExpiredTrash(ctx, local); err != nil {
+		t.Fatalf("reapExpiredTrash failed, %v", err)
+	}
+
+	entry := trashEntryName("foo", "expired-obj", fi.VersionID)
+	if _, err := local.ReadAll(ctx, minioMetaTrashBucket, pathJoin(entry, xlStorageFormatFile)); err == nil {
+		t.Fatal("expected trash entry to be purged")
+	}
+	if err := restClient.RestoreVersion(ctx, "foo", "expired-obj", fi.VersionID); err == nil {
+		t.Fatal("expected restore of purged version to fail")
+	}
+}
+
+func TestStorageRESTClientDataDirs(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+	local := globalLocalSetDrives[0][0][0]
+
+	// Three versions, each with its own data dir.
+	var dataDirs []string
+	for i := 0; i < 3; i++ {
+		fi := newSwapTestFileInfo("foo", "frag-obj", []byte(fmt.Sprintf("payload-%d", i)))
+		fi.VersionID = mustGetUUID()
+		fi.DataDir = mustGetUUID()
+		if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		dataDirs = append(dataDirs, fi.DataDir)
+	}
+
+	// Plant a stale data dir no version references, it must not be
+	// counted.
+	if err := local.AppendFile(ctx, "foo", pathJoin("frag-obj", mustGetUUID(), "part.1"), []byte("stale")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	info, err := restClient.DataDirs(ctx, "foo", "frag-obj")
+	if err != nil {
+		t.Fatalf("DataDirs failed, %v", err)
+	}
+	if info.Count != len(dataDirs) || len(info.Dirs) != len(dataDirs) {
+		t.Fatalf("expected %d data dirs, got count %d dirs %v", len(dataDirs), info.Count, info.Dirs)
+	}
+	seen := make(map[string]bool)
+	for _, dir := range info.Dirs {
+		seen[dir] = true
+	}
+	for _, dir := range dataDirs {
+		if !seen[dir] {
+			t.Errorf("expected data dir %v to be reported", dir)
+		}
+	}
+
+	if _, err = restClient.DataDirs(ctx, "foo", "frag-missing"); err != errFileNotFound {
+		t.Errorf("expected errFileNotFound, got %v", err)
+	}
+}
+
+func TestStorageRESTClientVolExists(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Probe and full stat must agree for an existing volume.
+	exists, err := restClient.VolExists(ctx, "foo")
+	if err != nil {
+		t.Fatalf("VolExists failed, %v", err)
+	}
+	if !exists {
+		t.Fatal("expected volume foo to exist")
+	}
+	if _, err = restClient.StatVol(ctx, "foo"); err != nil {
+		t.Fatalf("StatVol disagrees with probe, %v", err)
+	}
+
+	// And for a missing one.
+	exists, err = restClient.VolExists(ctx, "missing-vol")
+	if err != nil {
+		t.Fatalf("VolExists failed, %v", err)
+	}
+	if exists {
+		t.Fatal("expected volume missing-vol to not exist")
+	}
+	if _, err = restClient.StatVol(ctx, "missing-vol"); err != errVolumeNotFound {
+		t.Fatalf("expected errVolumeNotFound from StatVol, got %v", err)
+	}
+
+	// Invalid names are rejected rather than probed.
+	if _, err = restClient.VolExists(ctx, "ab"); err != errInvalidArgument {
+		t.Fatalf("expected errInvalidArgument, got %v", err)
+	}
+}
+
+func TestStorageRESTClientTaskQueueDepths(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	queue := driveTaskQueueFor(restClient.endpoint.String())
+	queue.enqueue(taskQueueScanner)
+	queue.enqueue(taskQueueScanner)
+	queue.enqueue(taskQueueHeal)
+	t.Cleanup(func() {
+		queue.dequeue(taskQueueScanner)
+		queue.dequeue(taskQueueHeal)
+	})
+
+	depths, err := restClient.TaskQueueDepths(ctx)
+	if err != nil {
+		t.Fatalf("TaskQueueDepths failed, %v", err)
+	}
+	if depths.Depths[taskQueueScanner] != 2 {
+		t.Errorf("expected scanner depth 2, got %d", depths.Depths[taskQueueScanner])
+	}
+	if depths.Depths[taskQueueHeal] != 1 {
+		t.Errorf("expected heal depth 1, got %d", depths.Depths[taskQueueHeal])
+	}
+	if depths.Depths[taskQueueCleanup] != 0 {
+		t.Errorf("expected cleanup depth 0, got %d", depths.Depths[taskQueueCleanup])
+	}
+
+	// Finishing a task lowers the reported depth.
+	queue.dequeue(taskQueueScanner)
+	depths, err = restClient.TaskQueueDepths(ctx)
+	if err != nil {
+		t.Fatalf("TaskQueueDepths failed, %v", err)
+	}
+	if depths.Depths[taskQueueScanner] != 1 {
+		t.Errorf("expected scanner depth 1, got %d", depths.Depths[taskQueueScanner])
+	}
+}
+
+func TestSniffContentType(t *testing.T) {
+	pngHeader := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0, 0, 0, 13}
+	testCases := []struct {
+		data []byte
+		want string
+	}{
+		{pngHeader, "image/png"},
+		{[]byte(`{"key": "value"}`), "application/json"},
+		{[]byte(`  [1, 2, 3]`), "application/json"},
+		{[]byte("plain text content"), "text/plain; charset=utf-8"},
+		{[]byte{0x00, 0x01, 0x02, 0xff, 0xfe}, "application/octet-stream"},
+	}
+	for i, testCase := range testCases {
+		if got := sniffContentType(testCase.data); got != testCase.want {
+			t.Errorf("case %v: expected %v, got %v", i+1, testCase.want, got)
+		}
+	}
+}
+
+func TestStorageRESTSniffedReadAll(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	content := []byte(`{"hello": "world"}`)
+	if err := restClient.WriteAll(ctx, "foo", "sniff-obj", content); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// The REST client does not expose response headers, issue the
+	// request directly to inspect the sniffed Content-Type.
+	values := make(url.Values)
+	values.Set(storageRESTVolume, "foo")
+	values.Set(storageRESTFilePath, "sniff-obj")
+	values.Set(storageRESTSniff, "true")
+	endpoint := restClient.endpoint
+	reqURL := "http://" + endpoint.Host + pathJoin(storageRESTPrefix, endpoint.Path) + storageRESTVersionPrefix + storageRESTMethodReadAll + "?" + values.Encode()
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+globalNodeAuthToken)
+	req.Header.Set("X-Minio-Time", strconv.FormatInt(time.Now().UnixNano(), 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %v", resp.Status)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected sniffed content-type application/json, got %v", got)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(body, content) {
+		t.Errorf("expected body to be unchanged by sniffing")
+	}
+}
+
+func TestStorageRESTClientLifecycleEval(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	writeObj := func(name string, age time.Duration) FileInfo {
+		fi := newSwapTestFileInfo("foo", name, []byte("lifecycle payload"))
+		fi.VersionID = mustGetUUID()
+		fi.ModTime = UTCNow().Add(-age)
+		if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		return fi
+	}
+
+	expireRule := []byte(`<LifecycleConfiguration><Rule><ID>expire-old</ID><Status>Enabled</Status><Filter></Filter><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`)
+	transitionRule := []byte(`<LifecycleConfiguration><Rule><ID>tier-old</ID><Status>Enabled</Status><Filter></Filter><Transition><Days>1</Days><StorageClass>COLDTIER</StorageClass></Transition></Rule></LifecycleConfiguration>`)
+
+	// Old object matched by an expiration rule.
+	old := writeObj("lc-expire", 48*time.Hour)
+	result, err := restClient.LifecycleEval(ctx, "foo", "lc-expire", old.VersionID, expireRule, false)
+	if err != nil {
+		t.Fatalf("LifecycleEval failed, %v", err)
+	}
+	if result.Action != lifecycleEvalExpire || result.RuleID != "expire-old" {
+		t.Errorf("expected expire by rule expire-old, got %v by %v", result.Action, result.RuleID)
+	}
+	// Dry run must not delete anything.
+	if _, err = restClient.ReadVersion(ctx, "", "foo", "lc-expire", old.VersionID, ReadOptions{}); err != nil {
+		t.Errorf("expected version to survive dry run, got %v", err)
+	}
+
+	// Old object matched by a transition rule.
+	oldTier := writeObj("lc-transition", 48*time.Hour)
+	result, err = restClient.LifecycleEval(ctx, "foo", "lc-transition", oldTier.VersionID, transitionRule, false)
+	if err != nil {
+		t.Fatalf("LifecycleEval failed, %v", err)
+	}
+	if result.Action != lifecycleEvalTransition {
+		t.Errorf("expected transition, got %v", result.Action)
+	}
+
+	// Fresh object stays put.
+	fresh := writeObj("lc-fresh", time.Minute)
+	result, err = restClient.LifecycleEval(ctx, "foo", "lc-fresh", fresh.VersionID, expireRule, false)
+	if err != nil {
+		t.Fatalf("LifecycleEval failed, %v", err)
+	}
+	if result.Action != lifecycleEvalNone {
+		t.Errorf("expected none, got %v", result.Action)
+	}
+
+	// Applying an expiry outcome deletes the version.
+	result, err = restClient.LifecycleEval(ctx, "foo", "lc-expire", old.VersionID, expireRule, true)
+	if err != nil {
+		t.Fatalf("LifecycleEval failed, %v", err)
+	}
+	if result.Action != lifecycleEvalExpire {
+		t.Errorf("expected expire, got %v", result.Action)
+	}
+	if _, err = restClient.ReadVersion(ctx, "", "foo", "lc-expire", old.VersionID, ReadOptions{}); err == nil {
+		t.Error("expected version to be deleted when applied")
+	}
+}
+
+func TestStorageRESTClientRawPartPaths(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+	local := globalLocalSetDrives[0][0][0]
+
+	// Non-inline version with two parts.
+	data := []byte("part contents")
+	fi := newSwapTestFileInfo("foo", "raw-obj", data)
+	fi.VersionID = mustGetUUID()
+	fi.DataDir = mustGetUUID()
+	fi.Metadata = map[string]string{}
+	fi.Data = nil
+	fi.Parts = []ObjectPartInfo{
+		{Number: 1, Size: int64(len(data)), ActualSize: int64(len(data))},
+		{Number: 2, Size: int64(len(data)), ActualSize: int64(len(data))},
+	}
+	for _, part := range fi.Parts {
+		if err := local.AppendFile(ctx, "foo", pathJoin("raw-obj", fi.DataDir, fmt.Sprintf("part.%d", part.Number)), data); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	paths, err := restClient.RawPartPaths(ctx, "foo", "raw-obj", fi.VersionID)
+	if err != nil {
+		t.Fatalf("RawPartPaths failed, %v", err)
+	}
+	root := restClient.endpoint.Path
+	if !strings.HasPrefix(paths.XLMeta, root+SlashSeparator) || !strings.HasSuffix(paths.XLMeta, pathJoin("foo", "raw-obj", xlStorageFormatFile)) {
+		t.Errorf("unexpected xl.meta path %v", paths.XLMeta)
+	}
+	if len(paths.Parts) != len(fi.Parts) {
+		t.Fatalf("expected %d part paths, got %v", len(fi.Parts), paths.Parts)
+	}
+	for i, p := range paths.Parts {
+		if !strings.HasPrefix(p, root+SlashSeparator) {
+			t.Errorf("part path %v escapes drive root %v", p, root)
+		}
+		if !strings.HasSuffix(p, pathJoin("raw-obj", fi.DataDir, fmt.Sprintf("part.%d", i+1))) {
+			t.Errorf("unexpected part path %v", p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("part path %v does not exist on disk, %v", p, err)
+		}
+	}
+
+	if _, err = restClient.RawPartPaths(ctx, "foo", "raw-missing", ""); err == nil {
+		t.Error("expected error for missing object")
+	}
+}
+
+func TestStorageRESTClientDeleteVersionsStream(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Small batch exercising the in-memory streaming path with mixed
+	// successes and failures.
+	total := 10
+	existing := map[int]struct{}{1: {}, 4: {}, 8: {}}
+	versions := make([]FileInfoVersions, total)
+	for i := range versions {
+		object := fmt.Sprintf("stream-object-%d", i)
+		if _, ok := existing[i]; ok {
+			wfi := newSwapTestFileInfo("foo", object, []byte("stream"))
+			if err := restClient.WriteMetadata(ctx, "", "foo", object, wfi); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+		}
+		versions[i] = FileInfoVersions{
+			Name:     object,
+			Volume:   "foo",
+			Versions: []FileInfo{{Name: object, Volume: "foo", ModTime: UTCNow()}},
+		}
+	}
+
+	errs := restClient.DeleteVersions(ctx, "foo", versions, DeleteOptions{})
+	if len(errs) != total {
+		t.Fatalf("expected %d results, got %d", total, len(errs))
+	}
+	for i, err := range errs {
+		if _, ok := existing[i]; ok {
+			if err != nil {
+				t.Errorf("index %d: expected successful delete, got %v", i, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("index %d: expected error for non-existent object", i)
+		}
+	}
+}
+
+func TestStorageRESTClientReadVersions(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Write a handful of objects, remembering every other one so the
+	// batch mixes hits and misses.
+	entries := make([]ReadVersionsEntry, 6)
+	want := make([]bool, len(entries))
+	for i := range entries {
+		entries[i] = ReadVersionsEntry{
+			Volume:   "foo",
+			FilePath: fmt.Sprintf("batch-obj-%d", i),
+			ReadData: true,
+		}
+		if i%2 == 0 {
+			fi := newSwapTestFileInfo("foo", entries[i].FilePath, []byte(fmt.Sprintf("payload-%d", i)))
+			fi.VersionID = mustGetUUID()
+			if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			entries[i].VersionID = fi.VersionID
+			want[i] = true
+		} else {
+			entries[i].VersionID = mustGetUUID()
+		}
+	}
+
+	fis, errs := restClient.ReadVersions(ctx, entries)
+	if len(fis) != len(entries) || len(errs) != len(entries) {
+		t.Fatalf("expected %d results, got %d fis and %d errs", len(entries), len(fis), len(errs))
+	}
+	for i := range entries {
+		if want[i] {
+			if errs[i] != nil {
+				t.Errorf("entry %d: unexpected error %v", i, errs[i])
+				continue
+			}
+			if fis[i] == nil || fis[i].Name != entries[i].FilePath || fis[i].VersionID != entries[i].VersionID {
+				t.Errorf("entry %d: response does not match request, got %+v", i, fis[i])
+			}
+			continue
+		}
+		if fis[i] != nil || errs[i] == nil {
+			t.Errorf("entry %d: expected an error for a missing version, got fi %+v err %v", i, fis[i], errs[i])
+		}
+	}
+
+	// Oversized batches are rejected outright.
+	_, errs = restClient.ReadVersions(ctx, make([]ReadVersionsEntry, readVersionsMaxBatch+1))
+	for i, err := range errs {
+		if err == nil {
+			t.Fatalf("entry %d: expected an error for an oversized batch", i)
+		}
+	}
+
+	// An empty batch is a no-op.
+	fis, errs = restClient.ReadVersions(ctx, nil)
+	if len(fis) != 0 || len(errs) != 0 {
+		t.Errorf("expected empty results for an empty batch, got %d fis and %d errs", len(fis), len(errs))
+	}
+}
+
+func TestStorageRESTClientStorageEfficiency(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+	local := globalLocalSetDrives[0][0][0]
+
+	// Non-inline version with two part files of known size.
+	data := []byte("twenty-byte payload!")
+	fi := newSwapTestFileInfo("foo", "eff-obj", data)
+	fi.VersionID = mustGetUUID()
+	fi.DataDir = mustGetUUID()
+	fi.Metadata = map[string]string{}
+	fi.Data = nil
+	fi.Size = 2 * int64(len(data))
+	fi.Parts = []ObjectPartInfo{
+		{Number: 1, Size: int64(len(data)), ActualSize: int64(len(data))},
+		{Number: 2, Size: int64(len(data)), ActualSize: int64(len(data))},
+	}
+	for _, part := range fi.Parts {
+		if err := local.AppendFile(ctx, "foo", pathJoin("eff-obj", fi.DataDir, fmt.Sprintf("part.%d", part.Number)), data); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	eff, err := restClient.StorageEfficiency(ctx, "foo", "eff-obj")
+	if err != nil {
+		t.Fatalf("StorageEfficiency failed, %v", err)
+	}
+	if eff.LogicalSize != fi.Size {
+		t.Errorf("expected logical size %d, got %d", fi.Size, eff.LogicalSize)
+	}
+	st, err := os.Stat(pathJoin(restClient.endpoint.Path, "foo", "eff-obj", xlStorageFormatFile))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	wantPhysical := st.Size() + 2*int64(len(data))
+	if eff.PhysicalSize != wantPhysical {
+		t.Errorf("expected physical size %d, got %d", wantPhysical, eff.PhysicalSize)
+	}
+	wantRatio := float64(wantPhysical) / float64(fi.Size)
+	if eff.Ratio != wantRatio {
+		t.Errorf("expected ratio %v, got %v", wantRatio, eff.Ratio)
+	}
+
+	// Inline object: physical size is the xl.meta file alone.
+	inline := newSwapTestFileInfo("foo", "eff-inline", []byte("inline"))
+	if err := restClient.WriteMetadata(ctx, "", inline.Volume, inline.Name, inline); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	eff, err = restClient.StorageEfficiency(ctx, "foo", "eff-inline")
+	if err != nil {
+		t.Fatalf("StorageEfficiency failed, %v", err)
+	}
+	st, err = os.Stat(pathJoin(restClient.endpoint.Path, "foo", "eff-inline", xlStorageFormatFile))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if eff.LogicalSize != inline.Size || eff.PhysicalSize != st.Size() {
+		t.Errorf("expected logical %d physical %d, got logical %d physical %d",
+			inline.Size, st.Size(), eff.LogicalSize, eff.PhysicalSize)
+	}
+
+	if _, err = restClient.StorageEfficiency(ctx, "foo", "eff-missing"); err == nil {
+		t.Error("expected error for missing object")
+	}
+}
+
+func TestStorageRESTClientAppendFileCreate(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Default semantics: a missing file is created and appended to.
+	if err := restClient.AppendFile(ctx, "foo", "append-new", []byte("hello ")); err != nil {
+		t.Fatalf("AppendFile failed, %v", err)
+	}
+	if err := restClient.AppendFile(ctx, "foo", "append-new", []byte("world")); err != nil {
+		t.Fatalf("AppendFile failed, %v", err)
+	}
+	buf, err := restClient.ReadAll(ctx, "foo", "append-new")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if string(buf) != "hello world" {
+		t.Errorf("expected %q, got %q", "hello world", string(buf))
+	}
+
+	// Reject-if-absent: a missing path is refused outright.
+	if err := restClient.AppendFileIfExists(ctx, "foo", "append-missing", []byte("data")); err != errFileNotFound {
+		t.Errorf("expected errFileNotFound, got %v", err)
+	}
+	if _, err := restClient.ReadAll(ctx, "foo", "append-missing"); err == nil {
+		t.Error("expected rejected append to not create the file")
+	}
+
+	// Reject-if-absent appends normally once the file exists.
+	if err := restClient.AppendFileIfExists(ctx, "foo", "append-new", []byte("!")); err != nil {
+		t.Fatalf("AppendFileIfExists failed, %v", err)
+	}
+	buf, err = restClient.ReadAll(ctx, "foo", "append-new")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if string(buf) != "hello world!" {
+		t.Errorf("expected %q, got %q", "hello world!", string(buf))
+	}
+}
+
+func TestStorageRESTClientDeadlinePropagation(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// A deadline already in the past cancels the grid call on the
+	// server before the disk operation runs.
+	_, err := storageStatVolRPC.Call(ctx, restClient.gridConn, grid.NewMSSWith(map[string]string{
+		storageRESTDiskID:   "",
+		storageRESTVolume:   "foo",
+		storageRESTDeadline: strconv.FormatInt(time.Now().Add(-time.Second).UnixNano(), 10),
+	}))
+	if err = toStorageErr(err); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// A deadline in the future leaves the call unaffected.
+	vol, err := storageStatVolRPC.Call(ctx, restClient.gridConn, grid.NewMSSWith(map[string]string{
+		storageRESTDiskID:   "",
+		storageRESTVolume:   "foo",
+		storageRESTDeadline: strconv.FormatInt(time.Now().Add(time.Hour).UnixNano(), 10),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if vol.Name != "foo" {
+		t.Errorf("expected volume foo, got %v", vol.Name)
+	}
+
+	// REST path: an expired X-Minio-Deadline header cancels the
+	// request context before the disk operation runs.
+	if err := restClient.WriteAll(ctx, "foo", "deadline-obj", []byte("data")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	endpoint := restClient.endpoint
+	url := "http://" + endpoint.Host + pathJoin(storageRESTPrefix, endpoint.Path) + storageRESTVersionPrefix +
+		storageRESTMethodReadAll + "?vol=foo&fp=deadline-obj"
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+globalNodeAuthToken)
+	req.Header.Set("X-Minio-Time", strconv.FormatInt(time.Now().UnixNano(), 10))
+	req.Header.Set(xhttp.MinIODeadline, strconv.FormatInt(time.Now().Add(-time.Second).UnixNano(), 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 499 {
+		t.Errorf("expected status 499, got %v", resp.StatusCode)
+	}
+}
+
+func TestStorageRESTClientVersionsSummary(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Three versions with known sizes.
+	sizes := []int{10, 200, 3000}
+	var total int64
+	base := UTCNow().Add(-time.Hour)
+	for i, size := range sizes {
+		fi := newSwapTestFileInfo("foo", "summ-obj", bytes.Repeat([]byte("x"), size))
+		fi.VersionID = mustGetUUID()
+		fi.ModTime = base.Add(time.Duration(i) * time.Minute)
+		if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		total += int64(size)
+	}
+
+	summary, err := restClient.VersionsSummary(ctx, "foo", "summ-obj")
+	if err != nil {
+		t.Fatalf("VersionsSummary failed, %v", err)
+	}
+	if summary.Count != len(sizes) {
+		t.Errorf("expected %d versions, got %d", len(sizes), summary.Count)
+	}
+	if summary.TotalSize != total {
+		t.Errorf("expected total size %d, got %d", total, summary.TotalSize)
+	}
+
+	if _, err = restClient.VersionsSummary(ctx, "foo", "summ-missing"); err == nil {
+		t.Error("expected error for missing object")
+	}
+}
+
+func TestStorageRESTClientAppendFileStreaming(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Lower the in-memory cap so the body below takes the streaming
+	// path through pooled buffers.
+	saved := maxAppendInMemSize
+	maxAppendInMemSize = 1 << 10
+	t.Cleanup(func() { maxAppendInMemSize = saved })
+
+	// Body several times the cap and not a multiple of the pooled
+	// buffer size, so the final short chunk is exercised too.
+	data := bytes.Repeat([]byte("0123456789abcdef"), 16<<10) // 256 KiB
+	data = append(data, []byte("tail")...)
+	if err := restClient.AppendFile(ctx, "foo", "append-large", data); err != nil {
+		t.Fatalf("AppendFile failed, %v", err)
+	}
+	buf, err := restClient.ReadAll(ctx, "foo", "append-large")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(buf, data) {
+		t.Fatalf("streamed append mismatch, expected %d bytes got %d", len(data), len(buf))
+	}
+
+	// Appending again still lands after the streamed content.
+	if err := restClient.AppendFile(ctx, "foo", "append-large", []byte("!")); err != nil {
+		t.Fatalf("AppendFile failed, %v", err)
+	}
+	buf, err = restClient.ReadAll(ctx, "foo", "append-large")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(buf) != len(data)+1 || buf[len(buf)-1] != '!' {
+		t.Fatalf("expected %d bytes ending in '!', got %d", len(data)+1, len(buf))
+	}
+}
+
+func TestStorageRESTMsgpDecodeLimits(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+	endpoint := restClient.endpoint
+
+	post := func(method, params string, body []byte) *http.Response {
+		t.Helper()
+		url := "http://" + endpoint.Host + pathJoin(storageRESTPrefix, endpoint.Path) + storageRESTVersionPrefix + method + "?" + params
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+globalNodeAuthToken)
+		req.Header.Set("X-Minio-Time", strconv.FormatInt(time.Now().UnixNano(), 10))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		return resp
+	}
+
+	// An array header claiming 4 billion elements backed by a few
+	// bytes must be rejected up front, not allocated for.
+	huge := msgp.AppendArrayHeader(nil, 0xFFFFFFFF)
+	resp := post(storageRESTMethodVerifyFile, "vol=foo&fp=crafted", huge)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || !strings.Contains(string(body), errInvalidArgument.Error()) {
+		t.Errorf("expected clean rejection, got status %d body %q", resp.StatusCode, string(body))
+	}
+
+	// Same for a map header on the DeleteVersions path.
+	huge = msgp.AppendMapHeader(nil, 0xFFFFFFFF)
+	resp = post(storageRESTMethodDeleteVersions, "vol=foo&tvers=1", huge)
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || !strings.Contains(string(body), errInvalidArgument.Error()) {
+		t.Errorf("expected clean rejection, got status %d body %q", resp.StatusCode, string(body))
+	}
+
+	// Trailing garbage after the declared object count is rejected too.
+	fi := newSwapTestFileInfo("foo", "crafted", []byte("x"))
+	fiv := FileInfoVersions{Name: "crafted", Volume: "foo", Versions: []FileInfo{fi}}
+	payload, err := fiv.MarshalMsg(nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	resp = post(storageRESTMethodDeleteVersions, "vol=foo&tvers=1", append(payload, payload...))
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || !strings.Contains(string(body), errInvalidArgument.Error()) {
+		t.Errorf("expected clean rejection, got status %d body %q", resp.StatusCode, string(body))
+	}
+
+	// Well-formed requests still work end to end.
+	wfi := newSwapTestFileInfo("foo", "decode-ok", []byte("payload"))
+	if err := restClient.WriteMetadata(ctx, "", wfi.Volume, wfi.Name, wfi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	errs := restClient.DeleteVersions(ctx, "foo", []FileInfoVersions{
+		{Name: "decode-ok", Volume: "foo", Versions: []FileInfo{wfi}},
+	}, DeleteOptions{})
+	if errs[0] != nil {
+		t.Errorf("unexpected error %v", errs[0])
+	}
+}
+
+func TestStorageRESTReadFileStreamRange(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+	endpoint := restClient.endpoint
+
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	if err := restClient.WriteAll(ctx, "foo", "range-obj", data); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	get := func(rangeHdr string) *http.Response {
+		t.Helper()
+		url := "http://" + endpoint.Host + pathJoin(storageRESTPrefix, endpoint.Path) + storageRESTVersionPrefix +
+			storageRESTMethodReadFileStream + "?vol=foo&fp=range-obj&offset=0&length=100"
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+globalNodeAuthToken)
+		req.Header.Set("X-Minio-Time", strconv.FormatInt(time.Now().UnixNano(), 10))
+		if rangeHdr != "" {
+			req.Header.Set(xhttp.Range, rangeHdr)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		return resp
+	}
+
+	// Suffix range: the last ten bytes.
+	resp := get("bytes=-10")
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		t.Fatalf("expected 206, got %d", resp.StatusCode)
+	}
+	if cr := resp.Header.Get(xhttp.ContentRange); cr != "bytes 90-99/100" {
+		t.Errorf("unexpected Content-Range %q", cr)
+	}
+	if !bytes.Equal(body, data[90:]) {
+		t.Errorf("unexpected suffix range payload %v", body)
+	}
+
+	// Open-ended range.
+	resp = get("bytes=30-")
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		t.Fatalf("expected 206, got %d", resp.StatusCode)
+	}
+	if cr := resp.Header.Get(xhttp.ContentRange); cr != "bytes 30-99/100" {
+		t.Errorf("unexpected Content-Range %q", cr)
+	}
+	if cl := resp.Header.Get(xhttp.ContentLength); cl != "70" {
+		t.Errorf("unexpected Content-Length %q", cl)
+	}
+	if !bytes.Equal(body, data[30:]) {
+		t.Errorf("unexpected open-ended range payload %v", body)
+	}
+
+	// Out-of-bounds range is unsatisfiable.
+	resp = get("bytes=200-300")
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		t.Fatalf("expected 416, got %d", resp.StatusCode)
+	}
+	if cr := resp.Header.Get(xhttp.ContentRange); cr != "bytes */100" {
+		t.Errorf("unexpected Content-Range %q", cr)
+	}
+
+	// No Range header: unchanged wire format, payload plus the
+	// trailing status marker.
+	resp = get("")
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if len(body) != len(data)+1 || !bytes.Equal(body[:len(data)], data) || body[len(data)] != 0 {
+		t.Errorf("unexpected unranged payload length %d", len(body))
+	}
+}
+
+func TestStorageRESTClientObjectSetDigest(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Use a fresh volume so objects written by other tests don't
+	// contribute to the digest.
+	if err := globalLocalSetDrives[0][0][0].MakeVol(ctx, "oset"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	objects := []string{"alpha", "nested/beta", "nested/deep/gamma"}
+	for _, object := range objects {
+		fi := newSwapTestFileInfo("oset", object, []byte("digest"))
+		if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	digest, err := restClient.ObjectSetDigest(ctx, "oset")
+	if err != nil {
+		t.Fatalf("ObjectSetDigest failed, %v", err)
+	}
+	if digest.Count != len(objects) || len(digest.Entries) != len(objects) {
+		t.Fatalf("expected %d entries, got count %d entries %d", len(objects), digest.Count, len(digest.Entries))
+	}
+	if !sort.SliceIsSorted(digest.Entries, func(i, j int) bool { return digest.Entries[i] < digest.Entries[j] }) {
+		t.Error("expected entries to be sorted")
+	}
+	var want uint64
+	for _, h := range digest.Entries {
+		want ^= h
+	}
+	if digest.Digest != want {
+		t.Errorf("expected digest %x, got %x", want, digest.Digest)
+	}
+
+	// The digest is stable across reads.
+	again, err := restClient.ObjectSetDigest(ctx, "oset")
+	if err != nil {
+		t.Fatalf("ObjectSetDigest failed, %v", err)
+	}
+	if again.Digest != digest.Digest {
+		t.Errorf("expected stable digest, got %x then %x", digest.Digest, again.Digest)
+	}
+
+	// Adding an object changes the digest and the count.
+	fi := newSwapTestFileInfo("oset", "delta", []byte("digest"))
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	after, err := restClient.ObjectSetDigest(ctx, "oset")
+	if err != nil {
+		t.Fatalf("ObjectSetDigest failed, %v", err)
+	}
+	if after.Count != len(objects)+1 {
+		t.Errorf("expected count %d, got %d", len(objects)+1, after.Count)
+	}
+	if after.Digest == digest.Digest {
+		t.Error("expected digest to change after adding an object")
+	}
+}
+
+func TestStorageStatusCode(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code int
+	}{
+		{errDiskStale, http.StatusPreconditionFailed},
+		{errFileNotFound, http.StatusNotFound},
+		{errFileVersionNotFound, http.StatusNotFound},
+		{errAuthentication, http.StatusUnauthorized},
+		{errSkewedAuthTime, http.StatusUnauthorized},
+		{errDiskFull, http.StatusInsufficientStorage},
+		{errFaultyDisk, http.StatusServiceUnavailable},
+		{errFaultyRemoteDisk, http.StatusServiceUnavailable},
+		{context.Canceled, 499},
+		{context.DeadlineExceeded, 499},
+		{errDiskAccessDenied, http.StatusForbidden},
+		{errInvalidArgument, http.StatusForbidden},
+		{errors.New("some other error"), http.StatusForbidden},
+	}
+	for _, testCase := range testCases {
+		if code := storageStatusCode(testCase.err); code != testCase.code {
+			t.Errorf("%v: expected status %d, got %d", testCase.err, testCase.code, code)
+		}
+	}
+
+	// The classification survives the round-trip through the REST
+	// error body, so callers see errDiskFull and not a generic 403.
+	if err := toStorageErr(errors.New(errDiskFull.Error())); err != errDiskFull {
+		t.Errorf("expected errDiskFull after round-trip, got %v", err)
+	}
+}
+
+func TestStorageRESTClientShortShard(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	data := bytes.Repeat([]byte("shard-data"), 100) // 1000 bytes
+	if err := restClient.WriteAll(ctx, "foo", "short-shard", data); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// Intact shard reads fine.
+	buf := make([]byte, len(data))
+	if _, err := restClient.ReadFile(ctx, "foo", "short-shard", 0, buf, nil); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(buf, data) {
+		t.Fatal("unexpected shard content")
+	}
+
+	// Truncate the shard behind the storage layer's back; reading the
+	// full expected length must now fail with errShortShard instead
+	// of silently returning partial data.
+	if err := os.Truncate(pathJoin(restClient.endpoint.Path, "foo", "short-shard"), 100); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := restClient.ReadFile(ctx, "foo", "short-shard", 0, buf, nil); err != errShortShard {
+		t.Errorf("expected errShortShard, got %v", err)
+	}
+
+	// A read that still fits within the truncated shard succeeds.
+	if _, err := restClient.ReadFile(ctx, "foo", "short-shard", 0, make([]byte, 100), nil); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestStorageRESTKeepAliveEarlyFiller(t *testing.T) {
+	restoreInterval, restoreFirstByte := keepAliveInterval, keepAliveFirstByte
+	keepAliveInterval, keepAliveFirstByte = 50*time.Millisecond, 10*time.Millisecond
+	defer func() {
+		keepAliveInterval, keepAliveFirstByte = restoreInterval, restoreFirstByte
+	}()
+
+	rec := httptest.NewRecorder()
+	done := keepHTTPResponseAlive(rec, "test-keepalive")
+
+	// Simulate an operation slower than both the first-byte delay and the
+	// regular interval.
+	time.Sleep(200 * time.Millisecond)
+	done(nil)
+
+	body := rec.Body.Bytes()
+	if len(body) < 2 {
+		t.Fatalf("expected filler bytes before completion, got %d bytes", len(body))
+	}
+	if body[0] != 32 {
+		t.Fatalf("expected first byte to be filler (32), got %d", body[0])
+	}
+	if body[len(body)-1] != 0 {
+		t.Fatalf("expected success marker (0) as last byte, got %d", body[len(body)-1])
+	}
+
+	// The receiving side must skip the filler bytes transparently.
+	rc, err := waitForHTTPResponse(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("waitForHTTPResponse failed: %v", err)
+	}
+	if b, err := io.ReadAll(rc); err != nil || len(b) != 0 {
+		t.Fatalf("expected empty response, got %d bytes, err %v", len(b), err)
+	}
+}
+
+func TestStorageRESTClientFillerStats(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	restoreInterval, restoreFirstByte := keepAliveInterval, keepAliveFirstByte
+	keepAliveInterval, keepAliveFirstByte = 10*time.Millisecond, 5*time.Millisecond
+	defer func() {
+		keepAliveInterval, keepAliveFirstByte = restoreInterval, restoreFirstByte
+	}()
+
+	before, err := restClient.FillerStats(ctx, false)
+	if err != nil {
+		t.Fatalf("FillerStats failed: %v", err)
+	}
+
+	// Simulate a slow operation forcing filler bytes.
+	rec := httptest.NewRecorder()
+	done := keepHTTPResponseAlive(rec, "slow-op")
+	time.Sleep(50 * time.Millisecond)
+	done(nil)
+
+	after, err := restClient.FillerStats(ctx, true)
+	if err != nil {
+		t.Fatalf("FillerStats failed: %v", err)
+	}
+	if after.Counts["slow-op"] <= before.Counts["slow-op"] {
+		t.Fatalf("expected filler count to rise, before %d after %d", before.Counts["slow-op"], after.Counts["slow-op"])
+	}
+
+	// The previous call reset the counters.
+	cleared, err := restClient.FillerStats(ctx, false)
+	if err != nil {
+		t.Fatalf("FillerStats failed: %v", err)
+	}
+	if n := cleared.Counts["slow-op"]; n != 0 {
+		t.Fatalf("expected counters to be reset, got %d", n)
+	}
+}
+
+func TestStorageRESTInternodeHandlerStats(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	sumStats := func(method string) (requests, errors, latency uint64) {
+		for _, methods := range globalInternodeHandlerStats.snapshot() {
+			st := methods[method]
+			requests += st.Requests
+			errors += st.Errors
+			for _, n := range st.Latency {
+				latency += n
+			}
+		}
+		return requests, errors, latency
+	}
+
+	reqBefore, _, _ := sumStats("StatVol")
+	_, errBefore, _ := sumStats("vsumm")
+
+	// A grid single-handler call that succeeds.
+	if _, err := restClient.StatVol(ctx, "foo"); err != nil {
+		t.Fatalf("StatVol failed: %v", err)
+	}
+	// An HTTP handler call that fails.
+	if _, err := restClient.VersionsSummary(ctx, "foo", "missing-object"); err == nil {
+		t.Fatal("expected VersionsSummary on missing object to fail")
+	}
+
+	reqAfter, _, latAfter := sumStats("StatVol")
+	if reqAfter <= reqBefore {
+		t.Fatalf("expected StatVol request count to rise, before %d after %d", reqBefore, reqAfter)
+	}
+	if latAfter != reqAfter {
+		t.Fatalf("expected latency histogram total %d to match request count %d", latAfter, reqAfter)
+	}
+	_, errAfter, _ := sumStats("vsumm")
+	if errAfter <= errBefore {
+		t.Fatalf("expected vsumm error count to rise, before %d after %d", errBefore, errAfter)
+	}
+
+	// The flattened madmin representation carries the same counters.
+	for endpoint, methods := range globalInternodeHandlerStats.snapshot() {
+		if st, ok := methods["StatVol"]; ok && st.Requests > 0 {
+			ops := globalInternodeHandlerStats.lifeTimeOps(endpoint)
+			if ops["internode.StatVol"] != st.Requests {
+				t.Fatalf("expected internode.StatVol = %d, got %d", st.Requests, ops["internode.StatVol"])
+			}
+		}
+	}
+}
+
+func TestStorageRESTClientRehydrate(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// An object whose data is local is a no-op.
+	local := newSwapTestFileInfo("foo", "rehyd-local", []byte("local-data"))
+	if err := restClient.WriteMetadata(ctx, "", local.Volume, local.Name, local); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := restClient.Rehydrate(ctx, "foo", "rehyd-local", ""); err != nil {
+		t.Fatalf("expected no-op for local object, got %v", err)
+	}
+
+	// A transitioned object attempts rehydration; without an object
+	// layer in this harness initiating the restore fails.
+	remote := newSwapTestFileInfo("foo", "rehyd-remote", nil)
+	remote.TransitionStatus = lifecycle.TransitionComplete
+	if err := restClient.WriteMetadata(ctx, "", remote.Volume, remote.Name, remote); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := restClient.Rehydrate(ctx, "foo", "rehyd-remote", ""); err == nil {
+		t.Fatal("expected rehydration initiation to fail without an object layer")
+	}
+
+	// A rehydration already in progress returns immediately.
+	key := pathJoin("foo", "rehyd-remote", "")
+	globalRehydrateMu.Lock()
+	globalRehydrateInProgress[key] = struct{}{}
+	globalRehydrateMu.Unlock()
+	defer func() {
+		globalRehydrateMu.Lock()
+		delete(globalRehydrateInProgress, key)
+		globalRehydrateMu.Unlock()
+	}()
+	if err := restClient.Rehydrate(ctx, "foo", "rehyd-remote", ""); err != nil {
+		t.Fatalf("expected in-progress rehydration to be a no-op, got %v", err)
+	}
+
+	// Missing objects report the read error.
+	if err := restClient.Rehydrate(ctx, "foo", "rehyd-missing", ""); err == nil {
+		t.Fatal("expected error for missing object")
+	}
+}
+
+func TestValidateStorageRequestTokenRotation(t *testing.T) {
+	savedCred := globalActiveCred
+	defer func() {
+		globalActiveCred = savedCred
+		globalValidatedTokens.lookup("") // re-sync the cache with the restored credentials
+	}()
+	globalActiveCred = auth.Credentials{AccessKey: "rotate-access", SecretKey: "rotate-secret-value"}
+
+	token, err := authenticateNode(globalActiveCred.AccessKey, globalActiveCred.SecretKey)
+	if err != nil {
+		t.Fatalf("authenticateNode failed: %v", err)
+	}
+	if err = validateStorageRequestToken(token); err != nil {
+		t.Fatalf("expected token to validate, got %v", err)
+	}
+	if !globalValidatedTokens.lookup(token) {
+		t.Fatal("expected validated token to be cached")
+	}
+
+	// Rotating the credentials must bust the cache and fail the old
+	// token outright.
+	globalActiveCred = auth.Credentials{AccessKey: "rotate-access", SecretKey: "rotated-secret-value"}
+	if globalValidatedTokens.lookup(token) {
+		t.Fatal("expected credential rotation to empty the token cache")
+	}
+	if err = validateStorageRequestToken(token); err == nil {
+		t.Fatal("expected token signed with old credentials to fail")
+	}
+
+	// A failed validation must not populate the cache.
+	if globalValidatedTokens.lookup(token) {
+		t.Fatal("expected failed token to stay uncached")
+	}
+}
+
+func BenchmarkValidateStorageRequestToken(b *testing.B) {
+	savedCred := globalActiveCred
+	defer func() {
+		globalActiveCred = savedCred
+		globalValidatedTokens.lookup("")
+	}()
+	globalActiveCred = auth.Credentials{AccessKey: "bench-access", SecretKey: "bench-secret-value"}
+
+	token, err := authenticateNode(globalActiveCred.AccessKey, globalActiveCred.SecretKey)
+	if err != nil {
+		b.Fatalf("authenticateNode failed: %v", err)
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			globalValidatedTokens.mu.Lock()
+			globalValidatedTokens.tokens = make(map[string]time.Time)
+			globalValidatedTokens.mu.Unlock()
+			if err := validateStorageRequestToken(token); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		if err := validateStorageRequestToken(token); err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := validateStorageRequestToken(token); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestStorageRESTClientStatParts(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	localDrive := globalLocalSetDrives[0][0][0]
+	if err := localDrive.WriteAll(ctx, "foo", "statparts-obj/part.1", []byte("0123456789")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := localDrive.WriteAll(ctx, "foo", "statparts-obj/part.3", []byte("012")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	resp, err := restClient.StatParts(ctx, "foo", "statparts-obj", []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("StatParts failed: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+	for i, want := range []struct {
+		number int
+		exists bool
+		size   int64
+	}{
+		{1, true, 10},
+		{2, false, 0},
+		{3, true, 3},
+	} {
+		got := resp.Results[i]
+		if got.Number != want.number {
+			t.Errorf("result %d: expected part number %d, got %d", i, want.number, got.Number)
+		}
+		if got.Exists != want.exists {
+			t.Errorf("part %d: expected exists=%v, got %v", want.number, want.exists, got.Exists)
+		}
+		if got.Size != want.size {
+			t.Errorf("part %d: expected size %d, got %d", want.number, want.size, got.Size)
+		}
+		if want.exists && (got.Err != "" || got.ModTime.IsZero()) {
+			t.Errorf("part %d: expected clean stat, got err %q modtime %v", want.number, got.Err, got.ModTime)
+		}
+		if !want.exists && got.Err == "" {
+			t.Errorf("part %d: expected an error for missing part", want.number)
+		}
+	}
+}
+
+func TestScanErrorWindowDecay(t *testing.T) {
+	w := &scanErrorWindow{}
+	base := time.Now()
+	for i := 0; i < 3; i++ {
+		w.addAt(base)
+	}
+	w.addAt(base.Add(time.Minute))
+
+	if total, _ := w.rateAt(base.Add(time.Minute)); total != 4 {
+		t.Fatalf("expected 4 errors in window, got %d", total)
+	}
+	if total, perMinute := w.rateAt(base.Add(5 * time.Minute)); total != 4 {
+		t.Fatalf("expected errors to remain inside window, got %d", total)
+	} else if want := float64(4) / scanErrorWindowMinutes; perMinute != want {
+		t.Fatalf("expected per-minute rate %v, got %v", want, perMinute)
+	}
+
+	// The first burst ages out one window width after it happened,
+	// the later error a minute after that.
+	if total, _ := w.rateAt(base.Add(scanErrorWindowMinutes * time.Minute)); total != 1 {
+		t.Fatalf("expected only the later error to remain, got %d", total)
+	}
+	if total, _ := w.rateAt(base.Add((scanErrorWindowMinutes + 1) * time.Minute)); total != 0 {
+		t.Fatalf("expected window to fully decay, got %d", total)
+	}
+}
+
+func TestStorageRESTClientScanErrorRate(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	rate, err := restClient.ScanErrorRate(ctx)
+	if err != nil {
+		t.Fatalf("ScanErrorRate failed: %v", err)
+	}
+	if rate.WindowMinutes != scanErrorWindowMinutes {
+		t.Fatalf("expected window of %d minutes, got %d", scanErrorWindowMinutes, rate.WindowMinutes)
+	}
+	before := rate.Total
+
+	// The handler registered this drive's window on the first call,
+	// record errors against every known drive.
+	for _, w := range globalScanErrorWindows.all() {
+		w.add()
+		w.add()
+	}
+
+	rate, err = restClient.ScanErrorRate(ctx)
+	if err != nil {
+		t.Fatalf("ScanErrorRate failed: %v", err)
+	}
+	if rate.Total < before+2 {
+		t.Fatalf("expected windowed total to rise by 2, before %d after %d", before, rate.Total)
+	}
+}
+
+func TestStorageRESTClientReadTransformed(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Store s2-compressed content and read it back decompressed.
+	original := bytes.Repeat([]byte("transform pipeline test payload "), 64)
+	var compressed bytes.Buffer
+	sw := s2.NewWriter(&compressed)
+	if _, err := sw.Write(original); err != nil {
+		t.Fatalf("compress failed: %v", err)
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatalf("compress close failed: %v", err)
+	}
+	localDrive := globalLocalSetDrives[0][0][0]
+	if err := localDrive.WriteAll(ctx, "foo", "transformed-obj", compressed.Bytes()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	rc, err := restClient.ReadTransformed(ctx, "foo", "transformed-obj", []string{"decompress:s2"})
+	if err != nil {
+		t.Fatalf("ReadTransformed failed: %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("reading transformed stream failed: %v", err)
+	}
+	if !bytes.Equal(got, original) {
+		t.Fatalf("expected %d original bytes, got %d", len(original), len(got))
+	}
+
+	// An empty pipeline passes the stored bytes through untouched.
+	rc, err = restClient.ReadTransformed(ctx, "foo", "transformed-obj", nil)
+	if err != nil {
+		t.Fatalf("ReadTransformed failed: %v", err)
+	}
+	got, err = io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("reading stream failed: %v", err)
+	}
+	if !bytes.Equal(got, compressed.Bytes()) {
+		t.Fatal("expected raw stored bytes without a pipeline")
+	}
+
+	// Unknown transforms are rejected.
+	if _, err = restClient.ReadTransformed(ctx, "foo", "transformed-obj", []string{"rot13"}); err == nil {
+		t.Fatal("expected unknown transform to be rejected")
+	}
+}
+
+func TestStorageRESTHealthStructured(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Legacy probe: no body, status code is the whole answer.
+	endpoint := restClient.endpoint
+	reqURL := "http://" + endpoint.Host + pathJoin(storageRESTPrefix, endpoint.Path) + storageRESTVersionPrefix + storageRESTMethodHealth
+	req, err := http.NewRequest(http.MethodPost, reqURL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+globalNodeAuthToken)
+	req.Header.Set("X-Minio-Time", strconv.FormatInt(time.Now().UnixNano(), 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %v", resp.Status)
+	}
+	if len(body) != 0 {
+		t.Fatalf("expected empty body on legacy probe, got %d bytes", len(body))
+	}
+
+	// Structured probe decodes and mirrors the drive state.
+	info, err := restClient.DiskHealthInfo(ctx)
+	if err != nil {
+		t.Fatalf("DiskHealthInfo failed: %v", err)
+	}
+	if info.Faulty {
+		t.Fatal("expected test drive not to be marked faulty")
+	}
+	diskID, _ := globalLocalSetDrives[0][0][0].GetDiskID()
+	if info.DiskID != diskID {
+		t.Fatalf("expected disk ID %q, got %q", diskID, info.DiskID)
+	}
+}
+
+func TestStorageRESTClientReserveVersionID(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Concurrent reservations for the same path must all come back
+	// distinct.
+	const n = 16
+	ids := make([]string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			vid, err := restClient.ReserveVersionID(ctx, "foo", "reserve-obj")
+			if err != nil {
+				t.Errorf("ReserveVersionID failed: %v", err)
+				return
+			}
+			ids[i] = vid
+		}(i)
+	}
+	wg.Wait()
+	seen := make(map[string]struct{}, n)
+	for _, vid := range ids {
+		if vid == "" {
+			t.Fatal("expected a version-id for every reservation")
+		}
+		if _, dup := seen[vid]; dup {
+			t.Fatalf("duplicate version-id %q handed out", vid)
+		}
+		seen[vid] = struct{}{}
+	}
+
+	// Expired reservations are pruned when the path is touched again.
+	key := pathJoin("foo", "reserve-obj")
+	globalVersionIDReservations.Lock()
+	for vid := range globalVersionIDReservations.m[key] {
+		globalVersionIDReservations.m[key][vid] = UTCNow().Add(-time.Second)
+	}
+	globalVersionIDReservations.Unlock()
+	if _, err := restClient.ReserveVersionID(ctx, "foo", "reserve-obj"); err != nil {
+		t.Fatalf("ReserveVersionID failed: %v", err)
+	}
+	globalVersionIDReservations.Lock()
+	live := len(globalVersionIDReservations.m[key])
+	globalVersionIDReservations.Unlock()
+	if live != 1 {
+		t.Fatalf("expected only the fresh reservation to survive, got %d", live)
+	}
+
+	// Path-less reservations are rejected.
+	if _, err := restClient.ReserveVersionID(ctx, "foo", ""); err == nil {
+		t.Fatal("expected error for empty path")
+	}
+}
+
+func TestStorageRESTClientWriteAllReadAllHTTP(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Large payloads round-trip through the negotiated codec.
+	big := bytes.Repeat([]byte("compressible payload "), 1024)
+	if err := restClient.WriteAllHTTP(ctx, "foo", "compressed-obj", big); err != nil {
+		t.Fatalf("WriteAllHTTP failed: %v", err)
+	}
+	got, err := restClient.ReadAllHTTP(ctx, "foo", "compressed-obj")
+	if err != nil {
+		t.Fatalf("ReadAllHTTP failed: %v", err)
+	}
+	if !bytes.Equal(got, big) {
+		t.Fatalf("large payload mismatch, expected %d bytes got %d", len(big), len(got))
+	}
+
+	// Verify the payload was actually compressed on the wire when the
+	// caller advertises support.
+	endpoint := restClient.endpoint
+	values := make(url.Values)
+	values.Set(storageRESTVolume, "foo")
+	values.Set(storageRESTFilePath, "compressed-obj")
+	reqURL := "http://" + endpoint.Host + pathJoin(storageRESTPrefix, endpoint.Path) + storageRESTVersionPrefix + storageRESTMethodReadAll + "?" + values.Encode()
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+globalNodeAuthToken)
+	req.Header.Set("X-Minio-Time", strconv.FormatInt(time.Now().UnixNano(), 10))
+	req.Header.Set(xhttp.MinIOAcceptEncoding, internodeCodecS2)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	wire, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if resp.Header.Get(xhttp.ContentEncoding) != internodeCodecS2 {
+		t.Fatalf("expected Content-Encoding %q, got %q", internodeCodecS2, resp.Header.Get(xhttp.ContentEncoding))
+	}
+	if len(wire) >= len(big) {
+		t.Fatalf("expected wire payload smaller than %d bytes, got %d", len(big), len(wire))
+	}
+	if decoded, err := s2.Decode(nil, wire); err != nil || !bytes.Equal(decoded, big) {
+		t.Fatalf("wire payload did not decode back to the original: %v", err)
+	}
+
+	// Small payloads stay uncompressed, non-advertising callers always
+	// get raw bytes.
+	small := []byte("small payload")
+	if err := restClient.WriteAllHTTP(ctx, "foo", "small-obj", small); err != nil {
+		t.Fatalf("WriteAllHTTP failed: %v", err)
+	}
+	values.Set(storageRESTFilePath, "small-obj")
+	reqURL = "http://" + endpoint.Host + pathJoin(storageRESTPrefix, endpoint.Path) + storageRESTVersionPrefix + storageRESTMethodReadAll + "?" + values.Encode()
+	req, err = http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+globalNodeAuthToken)
+	req.Header.Set("X-Minio-Time", strconv.FormatInt(time.Now().UnixNano(), 10))
+	req.Header.Set(xhttp.MinIOAcceptEncoding, internodeCodecS2)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	wire, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if resp.Header.Get(xhttp.ContentEncoding) != "" {
+		t.Fatalf("expected no Content-Encoding on small payload, got %q", resp.Header.Get(xhttp.ContentEncoding))
+	}
+	if !bytes.Equal(wire, small) {
+		t.Fatalf("small payload mismatch: %q", wire)
+	}
+}
+
+func TestStreamListDirBudget(t *testing.T) {
+	entries := make([]string, 250)
+	for i := range entries {
+		entries[i] = fmt.Sprintf("obj-%03d", i)
+	}
+
+	// Clock that expires the budget after two batches.
+	calls := 0
+	deadline := time.Unix(1000, 0)
+	now := func() time.Time {
+		calls++
+		if calls > 2 {
+			return deadline.Add(time.Second)
+		}
+		return deadline.Add(-time.Second)
+	}
+	var got []string
+	var truncated bool
+	var token string
+	streamListDirBudget(entries, deadline, now, func(res *ListDirBudgetResult) {
+		if res.Truncated {
+			truncated = true
+			token = res.Token
+			return
+		}
+		got = append(got, res.Entries...)
+	})
+	if !truncated {
+		t.Fatal("expected listing to be truncated")
+	}
+	if len(got) != 2*listDirBudgetBatch {
+		t.Fatalf("expected %d entries before truncation, got %d", 2*listDirBudgetBatch, len(got))
+	}
+	if token != got[len(got)-1] {
+		t.Fatalf("expected token %q to be the last sent entry, got %q", got[len(got)-1], token)
+	}
+
+	// A generous clock sends everything with no truncation marker.
+	got, truncated = nil, false
+	streamListDirBudget(entries, deadline, func() time.Time { return deadline.Add(-time.Second) }, func(res *ListDirBudgetResult) {
+		if res.Truncated {
+			truncated = true
+			return
+		}
+		got = append(got, res.Entries...)
+	})
+	if truncated || len(got) != len(entries) {
+		t.Fatalf("expected full untruncated listing, got %d entries truncated=%v", len(got), truncated)
+	}
+}
+
+func TestStorageRESTClientListDirBudget(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	storage := globalLocalSetDrives[0][0][0]
+	total := 250
+	for i := 0; i < total; i++ {
+		if err := storage.WriteAll(ctx, "foo", pathJoin("budget-dir", fmt.Sprintf("obj-%03d", i)), []byte("x")); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	// A generous budget returns the complete listing.
+	entries, truncated, _, err := restClient.ListDirBudget(ctx, "", "foo", "budget-dir", -1, time.Minute, "")
+	if err != nil {
+		t.Fatalf("ListDirBudget failed: %v", err)
+	}
+	if truncated {
+		t.Fatal("expected untruncated listing within a generous budget")
+	}
+	if len(entries) != total {
+		t.Fatalf("expected %d entries, got %d", total, len(entries))
+	}
+
+	// A token resumes the listing after the given entry.
+	entries, truncated, _, err = restClient.ListDirBudget(ctx, "", "foo", "budget-dir", -1, time.Minute, "obj-099")
+	if err != nil {
+		t.Fatalf("ListDirBudget failed: %v", err)
+	}
+	if truncated || len(entries) != total-100 {
+		t.Fatalf("expected %d entries after token, got %d truncated=%v", total-100, len(entries), truncated)
+	}
+
+	// An exhausted budget truncates immediately.
+	entries, truncated, next, err := restClient.ListDirBudget(ctx, "", "foo", "budget-dir", -1, -time.Second, "")
+	if err != nil {
+		t.Fatalf("ListDirBudget failed: %v", err)
+	}
+	if !truncated {
+		t.Fatal("expected truncation with an exhausted budget")
+	}
+	if len(entries) != 0 || next != "" {
+		t.Fatalf("expected no entries and empty token, got %d entries token=%q", len(entries), next)
+	}
+}
+
+func TestStorageRESTClientReloadDrive(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	if err := restClient.WriteAllHTTP(ctx, "foo", "reload-obj", []byte("still here")); err != nil {
+		t.Fatalf("WriteAllHTTP failed: %v", err)
+	}
+
+	// Simulate a dead drive by removing its storage layer.
+	globalLocalDrivesMu.Lock()
+	globalLocalSetDrives[0][0][0] = nil
+	globalLocalDrivesMu.Unlock()
+	if _, err := restClient.DiskHealthInfo(ctx); err == nil {
+		t.Fatal("expected failure while the storage layer is gone")
+	}
+
+	// Reload brings the endpoint back online immediately.
+	st, err := restClient.ReloadDrive(ctx)
+	if err != nil {
+		t.Fatalf("ReloadDrive failed: %v", err)
+	}
+	if st.State != driveInitReady {
+		t.Fatalf("expected state %q, got %q (last error %q)", driveInitReady, st.State, st.LastErr)
+	}
+	got, err := restClient.ReadAllHTTP(ctx, "foo", "reload-obj")
+	if err != nil {
+		t.Fatalf("ReadAllHTTP after reload failed: %v", err)
+	}
+	if string(got) != "still here" {
+		t.Fatalf("unexpected content after reload: %q", got)
+	}
+
+	// A second reload on a serving drive is a no-op, in-flight
+	// requests keep their storage layer.
+	globalLocalDrivesMu.RLock()
+	before := globalLocalSetDrives[0][0][0]
+	globalLocalDrivesMu.RUnlock()
+	st, err = restClient.ReloadDrive(ctx)
+	if err != nil {
+		t.Fatalf("ReloadDrive failed: %v", err)
+	}
+	if st.State != driveInitReady {
+		t.Fatalf("expected state %q, got %q", driveInitReady, st.State)
+	}
+	globalLocalDrivesMu.RLock()
+	after := globalLocalSetDrives[0][0][0]
+	globalLocalDrivesMu.RUnlock()
+	if before != after {
+		t.Fatal("expected reload of a serving drive to leave its storage layer untouched")
+	}
+}
+
+func TestStorageRESTClientObjectShardLayout(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	fi := newSwapTestFileInfo("foo", "shard-obj", []byte("shard-data"))
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	layout, err := restClient.ObjectShardLayout(ctx, "foo", "shard-obj", "")
+	if err != nil {
+		t.Fatalf("ObjectShardLayout failed: %v", err)
+	}
+	if layout.ShardIndex != fi.Erasure.Index {
+		t.Fatalf("expected shard index %d, got %d", fi.Erasure.Index, layout.ShardIndex)
+	}
+	if layout.DataBlocks != fi.Erasure.DataBlocks || layout.ParityBlocks != fi.Erasure.ParityBlocks {
+		t.Fatalf("unexpected shard counts %d/%d", layout.DataBlocks, layout.ParityBlocks)
+	}
+	if !slices.Equal(layout.Distribution, fi.Erasure.Distribution) {
+		t.Fatalf("expected distribution %v, got %v", fi.Erasure.Distribution, layout.Distribution)
+	}
+	endpoint := restClient.endpoint
+	if layout.PoolIdx != endpoint.PoolIdx || layout.SetIdx != endpoint.SetIdx || layout.DiskIdx != endpoint.DiskIdx {
+		t.Fatalf("expected drive position %d/%d/%d, got %d/%d/%d",
+			endpoint.PoolIdx, endpoint.SetIdx, endpoint.DiskIdx, layout.PoolIdx, layout.SetIdx, layout.DiskIdx)
+	}
+
+	// Missing objects report the read error.
+	if _, err := restClient.ObjectShardLayout(ctx, "foo", "shard-missing", ""); err == nil {
+		t.Fatal("expected error for missing object")
+	}
+}
+
+func TestStorageRESTClientVerifyFileProgress(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	storage := globalLocalSetDrives[0][0][0]
+	dataDir := mustGetUUID()
+	data := []byte("part contents, the checksums below are bogus")
+	fi := FileInfo{
+		Volume: "foo", Name: "verify-obj", DataDir: dataDir, ModTime: UTCNow(), Size: 3 * int64(len(data)),
+		Erasure: ErasureInfo{
+			Algorithm:    erasureAlgorithm,
+			DataBlocks:   4,
+			ParityBlocks: 4,
+			BlockSize:    blockSizeV2,
+			Index:        1,
+			Distribution: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+	}
+	for part := 1; part <= 3; part++ {
+		if err := storage.AppendFile(ctx, "foo", pathJoin("verify-obj", dataDir, fmt.Sprintf("part.%d", part)), data); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		fi.Parts = append(fi.Parts, ObjectPartInfo{Number: part, Size: int64(len(data)), ActualSize: int64(len(data))})
+		fi.Erasure.Checksums = append(fi.Erasure.Checksums, ChecksumInfo{PartNumber: part, Algorithm: SHA256, Hash: make([]byte, 32)})
+	}
+
+	var parts []int
+	var lastBytes int64
+	resp, err := restClient.VerifyFileProgress(ctx, "foo", "verify-obj", fi, func(part int, bytesDone int64) {
+		parts = append(parts, part)
+		if bytesDone <= lastBytes {
+			t.Errorf("expected bytesDone to increase, got %d after %d", bytesDone, lastBytes)
+		}
+		lastBytes = bytesDone
+	})
+	if err != nil {
+		t.Fatalf("VerifyFileProgress failed: %v", err)
+	}
+	if len(resp.Results) != len(fi.Parts) {
+		t.Fatalf("expected %d results, got %d", len(fi.Parts), len(resp.Results))
+	}
+	if !slices.Equal(parts, []int{0, 1, 2}) {
+		t.Fatalf("expected progress for parts [0 1 2], got %v", parts)
+	}
+	if want := 3 * fi.Erasure.ShardFileSize(int64(len(data))); lastBytes != want {
+		t.Fatalf("expected %d shard bytes verified, got %d", want, lastBytes)
+	}
+
+	// The single-result path is unaffected.
+	resp, err = storage.VerifyFile(ctx, "foo", "verify-obj", fi)
+	if err != nil {
+		t.Fatalf("VerifyFile failed: %v", err)
+	}
+	if len(resp.Results) != len(fi.Parts) {
+		t.Fatalf("expected %d results, got %d", len(fi.Parts), len(resp.Results))
+	}
+}
+
+func TestDriveWriteGateQueueing(t *testing.T) {
+	gate := newDriveWriteGate(1)
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		gate.run(ctx, "first", func() error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	// The second writer queues behind the first until it releases.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+	if err := gate.run(ctx, "second", func() error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	wg.Wait()
+
+	stats := gate.snapshot()
+	second, ok := stats["second"]
+	if !ok || second.Count != 1 {
+		t.Fatalf("expected one recorded call for the queued writer, got %+v", stats)
+	}
+	if queued := time.Duration(second.QueueNanos); queued < 25*time.Millisecond {
+		t.Fatalf("expected the queued writer to record queueing delay, got %v", queued)
+	}
+	if io := time.Duration(second.IONanos); io < 15*time.Millisecond {
+		t.Fatalf("expected the queued writer to record I/O time, got %v", io)
+	}
+	if first := stats["first"]; time.Duration(first.QueueNanos) > 10*time.Millisecond {
+		t.Fatalf("expected the first writer not to queue, got %v", time.Duration(first.QueueNanos))
+	}
+
+	// A cancelled context gives up waiting for a slot.
+	cctx, cancel := context.WithCancel(ctx)
+	blocked := make(chan struct{})
+	unblock := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		gate.run(ctx, "blocker", func() error {
+			close(blocked)
+			<-unblock
+			return nil
+		})
+	}()
+	<-blocked
+	cancel()
+	if err := gate.run(cctx, "late", func() error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	close(unblock)
+	wg.Wait()
+}
+
+func TestStorageRESTWriteGateRecords(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	countWriteAll := func() (n int64) {
+		for _, g := range globalDriveWriteGates.all() {
+			n += g.snapshot()["WriteAll"].Count
+		}
+		return n
+	}
+
+	before := countWriteAll()
+	if err := restClient.WriteAllHTTP(ctx, "foo", "gate-obj", []byte("gated write")); err != nil {
+		t.Fatalf("WriteAllHTTP failed: %v", err)
+	}
+	after := countWriteAll()
+	if after != before+1 {
+		t.Fatalf("expected WriteAll count to rise by one, before %d after %d", before, after)
+	}
+}
+
+func TestDriveHeavyGateLimit(t *testing.T) {
+	prevWait := heavyOpWait
+	heavyOpWait = 50 * time.Millisecond
+	defer func() { heavyOpWait = prevWait }()
+
+	gate := newDriveHeavyGate(2)
+	ctx := context.Background()
+
+	// The first N acquires succeed immediately.
+	for i := 0; i < 2; i++ {
+		if err := gate.acquire(ctx); err != nil {
+			t.Fatalf("acquire %d failed: %v", i, err)
+		}
+	}
+
+	// The N+1th queues up to the timeout and is rejected retryably.
+	if err := gate.acquire(ctx); !errors.Is(err, errDriveBusy) {
+		t.Fatalf("expected errDriveBusy, got %v", err)
+	}
+
+	// A freed slot lets a queued acquire through.
+	done := make(chan error, 1)
+	go func() { done <- gate.acquire(ctx) }()
+	gate.release()
+	if err := <-done; err != nil {
+		t.Fatalf("expected queued acquire to succeed, got %v", err)
+	}
+}
+
+func TestStorageRESTHeavyGateRejects(t *testing.T) {
+	prevWait := heavyOpWait
+	heavyOpWait = 50 * time.Millisecond
+	defer func() { heavyOpWait = prevWait }()
+
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	fi := newSwapTestFileInfo("foo", "heavy-obj", []byte("heavy"))
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// First call creates the drive's gate and succeeds.
+	if _, err := restClient.VerifyFileProgress(ctx, "foo", "heavy-obj", fi, nil); err != nil {
+		t.Fatalf("VerifyFileProgress failed: %v", err)
+	}
+
+	// Exhaust every known gate's slots, the next heavy call must be
+	// rejected with a retryable busy error.
+	gates := make([]*driveHeavyGate, 0)
+	for _, g := range globalDriveHeavyGates.all() {
+		gates = append(gates, g)
+	}
+	filled := make(map[*driveHeavyGate]int)
+	for _, g := range gates {
+	fill:
+		for {
+			select {
+			case g.slots <- struct{}{}:
+				filled[g]++
+			default:
+				break fill
+			}
+		}
+	}
+	defer func() {
+		for g, n := range filled {
+			for i := 0; i < n; i++ {
+				g.release()
+			}
+		}
+	}()
+
+	if _, err := restClient.VerifyFileProgress(ctx, "foo", "heavy-obj", fi, nil); !errors.Is(err, errDriveBusy) {
+		t.Fatalf("expected errDriveBusy, got %v", err)
+	}
+
+	// Cheap metadata ops stay responsive while the gate is full.
+	if _, err := restClient.StatVol(ctx, "foo"); err != nil {
+		t.Fatalf("expected ungated StatVol to succeed, got %v", err)
+	}
+}
+
+func TestMetaDigestScan(t *testing.T) {
+	objects := map[string][]byte{
+		"bucket/a/xl.meta": []byte("meta-a"),
+		"bucket/b/xl.meta": []byte("meta-b"),
+		"bucket/c/xl.meta": []byte("meta-c"),
+	}
+
+	fold := func(paths []string) *metaDigestScan {
+		scan := &metaDigestScan{}
+		for _, p := range paths {
+			scan.fold(p, objects[p])
+		}
+		return scan
+	}
+
+	forward := fold([]string{"bucket/a/xl.meta", "bucket/b/xl.meta", "bucket/c/xl.meta"})
+	reverse := fold([]string{"bucket/c/xl.meta", "bucket/b/xl.meta", "bucket/a/xl.meta"})
+	if forward.digest != reverse.digest {
+		t.Fatalf("digest depends on scan order: %x != %x", forward.digest, reverse.digest)
+	}
+	if forward.objects != 3 {
+		t.Fatalf("expected 3 objects folded, got %d", forward.objects)
+	}
+
+	again := fold([]string{"bucket/a/xl.meta", "bucket/b/xl.meta", "bucket/c/xl.meta"})
+	if forward.digest != again.digest {
+		t.Fatalf("digest is not stable: %x != %x", forward.digest, again.digest)
+	}
+
+	changed := &metaDigestScan{}
+	changed.fold("bucket/a/xl.meta", []byte("meta-a-modified"))
+	changed.fold("bucket/b/xl.meta", objects["bucket/b/xl.meta"])
+	changed.fold("bucket/c/xl.meta", objects["bucket/c/xl.meta"])
+	if changed.digest == forward.digest {
+		t.Fatal("expected digest to change when one object's metadata changes")
+	}
+}
+
+func TestStorageRESTClientMetadataDigest(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// No scanner pass has published anything yet.
+	digest, err := restClient.MetadataDigest(ctx)
+	if err != nil {
+		t.Fatalf("MetadataDigest failed: %v", err)
+	}
+	if digest.Digest != 0 || digest.Objects != 0 || !digest.Updated.IsZero() {
+		t.Fatalf("expected zero digest before any scan, got %+v", digest)
+	}
+
+	// Publish a known scan result for every known drive; the test
+	// server's endpoint is among them.
+	scan := &metaDigestScan{}
+	scan.fold("bucket/obj/xl.meta", []byte("meta"))
+	for _, d := range globalDriveMetaDigests.all() {
+		d.publish("bucket", scan)
+	}
+
+	digest, err = restClient.MetadataDigest(ctx)
+	if err != nil {
+		t.Fatalf("MetadataDigest failed: %v", err)
+	}
+	if digest.Digest != scan.digest || digest.Objects != 1 {
+		t.Fatalf("expected published digest %x/1, got %x/%d", scan.digest, digest.Digest, digest.Objects)
+	}
+	if digest.Updated.IsZero() {
+		t.Fatal("expected non-zero update time after publish")
+	}
+}
+
+func TestStorageRESTClientListDirChunked(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	storage := globalLocalSetDrives[0][0][0]
+	total := 2*listDirChunk + listDirChunk/2
+	for i := 0; i < total; i++ {
+		if err := storage.WriteAll(ctx, "foo", pathJoin("chunk-dir", fmt.Sprintf("obj-%04d", i)), []byte("x")); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	// ListDir now streams in chunks; the reassembled listing must match
+	// what the single-message legacy route returns.
+	chunked, err := restClient.ListDir(ctx, "", "foo", "chunk-dir", -1)
+	if err != nil {
+		t.Fatalf("ListDir failed: %v", err)
+	}
+
+	values := grid.NewMSS()
+	values.Set(storageRESTVolume, "foo")
+	values.Set(storageRESTDirPath, "chunk-dir")
+	values.Set(storageRESTCount, "-1")
+	values.Set(storageRESTOrigVolume, "")
+	values.Set(storageRESTDiskID, *restClient.diskID.Load())
+	st, err := storageListDirRPC.Call(ctx, restClient.gridConn, values)
+	if err != nil {
+		t.Fatalf("legacy ListDir failed: %v", err)
+	}
+	var legacy []string
+	if err = st.Results(func(resp *ListDirResult) error {
+		legacy = append(legacy, resp.Entries...)
+		return nil
+	}); err != nil {
+		t.Fatalf("legacy ListDir failed: %v", err)
+	}
+
+	if len(chunked) != total {
+		t.Fatalf("expected %d entries, got %d", total, len(chunked))
+	}
+	slices.Sort(chunked)
+	slices.Sort(legacy)
+	if !slices.Equal(chunked, legacy) {
+		t.Fatalf("chunked listing differs from legacy listing: %d vs %d entries", len(chunked), len(legacy))
+	}
+}
+
+func TestStorageRESTClientReadFileStreamVerified(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	content := make([]byte, 128<<10)
+	for i := range content {
+		content[i] = byte(i)
+	}
+	if err := restClient.WriteAll(ctx, "foo", "verified-stream", content); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// A clean stream verifies against the trailer digest.
+	rc, err := restClient.ReadFileStreamVerified(ctx, "foo", "verified-stream", 0, int64(len(content)), DefaultBitrotAlgorithm)
+	if err != nil {
+		t.Fatalf("ReadFileStreamVerified failed: %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("expected verified read to succeed, got %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatal("verified stream content mismatch")
+	}
+
+	// Sections verify too.
+	rc, err = restClient.ReadFileStreamVerified(ctx, "foo", "verified-stream", 100, 1000, DefaultBitrotAlgorithm)
+	if err != nil {
+		t.Fatalf("ReadFileStreamVerified failed: %v", err)
+	}
+	got, err = io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("expected verified section read to succeed, got %v", err)
+	}
+	if !bytes.Equal(got, content[100:1100]) {
+		t.Fatal("verified section content mismatch")
+	}
+
+	// A byte flipped in transit must be caught by the trailer digest.
+	values := make(url.Values)
+	values.Set(storageRESTVolume, "foo")
+	values.Set(storageRESTFilePath, "verified-stream")
+	values.Set(storageRESTOffset, "0")
+	values.Set(storageRESTLength, strconv.Itoa(len(content)))
+	values.Set(storageRESTDiskID, "")
+	header := make(http.Header)
+	header.Set(xhttp.MinIOReadHashAlgo, DefaultBitrotAlgorithm.String())
+	respBody, trailer, err := restClient.restClient.CallWithTrailer(ctx, http.MethodGet, storageRESTMethodReadFileStream, values, nil, -1, header)
+	if err != nil {
+		t.Fatalf("CallWithTrailer failed: %v", err)
+	}
+	raw, err := io.ReadAll(respBody)
+	respBody.Close()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if trailer.Get(xhttp.MinIOReadHashSum) == "" {
+		t.Fatal("expected digest trailer after draining the body")
+	}
+	raw[0] ^= 0xff
+	corrupted := &hashVerifyReader{
+		rc:      &trailingStatusReader{rc: io.NopCloser(bytes.NewReader(raw))},
+		h:       DefaultBitrotAlgorithm.New(),
+		trailer: trailer,
+	}
+	if _, err = io.ReadAll(corrupted); !errors.Is(err, errCorruptedData) {
+		t.Fatalf("expected errCorruptedData for corrupted stream, got %v", err)
+	}
+
+	// Unverified reads are unaffected by the new header gate.
+	rc, err = restClient.ReadFileStream(ctx, "foo", "verified-stream", 0, int64(len(content)))
+	if err != nil {
+		t.Fatalf("ReadFileStream failed: %v", err)
+	}
+	got, err = io.ReadAll(rc)
+	rc.Close()
+	if err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("plain stream read mismatch, err=%v", err)
+	}
+}
+
+func TestStorageRESTClientReadVersionCached(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	fi := newSwapTestFileInfo("foo", "cond-obj", []byte("conditional"))
+	if err := restClient.WriteMetadata(ctx, "", fi.Volume, fi.Name, fi); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	opts := ReadOptions{}
+	fresh, err := restClient.ReadVersion(ctx, "", "foo", "cond-obj", "", opts)
+	if err != nil {
+		t.Fatalf("ReadVersion failed: %v", err)
+	}
+
+	// Unmodified: the cached copy is returned as is; plant a marker in
+	// it to prove no wire payload replaced it.
+	cached := fresh
+	cached.Metadata = map[string]string{"x-test-marker": "cached"}
+	got, err := restClient.ReadVersionCached(ctx, "", "foo", "cond-obj", "", opts, &cached)
+	if err != nil {
+		t.Fatalf("ReadVersionCached failed: %v", err)
+	}
+	if got.Metadata["x-test-marker"] != "cached" {
+		t.Fatal("expected the cached FileInfo for an unmodified version")
+	}
+
+	// The REST data path honors the signature the same way.
+	got, err = restClient.ReadVersionCached(ctx, "", "foo", "cond-obj", "", ReadOptions{ReadData: true}, &cached)
+	if err != nil {
+		t.Fatalf("ReadVersionCached failed: %v", err)
+	}
+	if got.Metadata["x-test-marker"] != "cached" {
+		t.Fatal("expected the cached FileInfo for an unmodified version over REST")
+	}
+
+	// Modified: a stale signature pays for the full payload again.
+	stale := fresh
+	stale.ModTime = stale.ModTime.Add(-time.Second)
+	stale.Metadata = map[string]string{"x-test-marker": "stale"}
+	got, err = restClient.ReadVersionCached(ctx, "", "foo", "cond-obj", "", opts, &stale)
+	if err != nil {
+		t.Fatalf("ReadVersionCached failed: %v", err)
+	}
+	if got.Metadata["x-test-marker"] == "stale" {
+		t.Fatal("expected fresh metadata for a modified version")
+	}
+	if !got.ModTime.Equal(fresh.ModTime) {
+		t.Fatalf("expected on-disk modtime %v, got %v", fresh.ModTime, got.ModTime)
+	}
+
+	// Missing: stays a genuine not-found, never a not-modified.
+	_, err = restClient.ReadVersionCached(ctx, "", "foo", "cond-missing", "", opts, &cached)
+	if errors.Is(err, errFileVersionNotModified) || err == nil {
+		t.Fatalf("expected a not-found error for a missing version, got %v", err)
+	}
+	if !errors.Is(err, errFileNotFound) && !errors.Is(err, errFileVersionNotFound) {
+		t.Fatalf("expected not-found, got %v", err)
+	}
+}
+
+func TestStorageErrEnvelopeRoundTrip(t *testing.T) {
+	// Every coded sentinel must survive the envelope and come back as
+	// the exact canonical error the string matching used to produce.
+	for code, sentinel := range storageErrCode {
+		enc := storageErrEncode(sentinel)
+		dec, ok := storageErrDecode(enc)
+		if !ok {
+			t.Fatalf("code %d: %q did not decode as an envelope", code, enc)
+		}
+		if dec != sentinel {
+			t.Fatalf("code %d: decoded %v, want %v", code, dec, sentinel)
+		}
+		if got, want := toStorageErr(errors.New(enc)), toStorageErr(sentinel); !errors.Is(got, want) {
+			t.Fatalf("code %d: toStorageErr gave %v, want %v", code, got, want)
+		}
+	}
+
+	// Errors outside the table travel as code 0 and degrade to their
+	// text, which the legacy string matching can still canonicalize.
+	enc := storageErrEncode(fmt.Errorf("some wrapped thing: %w", errFileNotFound))
+	dec, ok := storageErrDecode(enc)
+	if !ok || dec.Error() != "some wrapped thing: "+errFileNotFound.Error() {
+		t.Fatalf("expected text-only fallback, got ok=%v err=%v", ok, dec)
+	}
+
+	// Unknown codes from a newer peer degrade to the message text.
+	dec, ok = storageErrDecode(`{"v":1,"c":999999,"m":"fancy new error"}`)
+	if !ok || dec.Error() != "fancy new error" {
+		t.Fatalf("expected graceful unknown-code decode, got ok=%v err=%v", ok, dec)
+	}
+
+	// Plain strings from older peers are not envelopes.
+	if _, ok = storageErrDecode(errFileNotFound.Error()); ok {
+		t.Fatal("plain error text must not decode as an envelope")
+	}
+	if got := toStorageErr(errors.New(errFileNotFound.Error())); got != errFileNotFound {
+		t.Fatalf("legacy string matching broke: %v", got)
+	}
+}
+
+func TestStorageRESTErrorEnvelopeOnWire(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	endpoint := restClient.endpoint
+
+	// A failing handler must answer with the coded envelope body.
+	values := make(url.Values)
+	values.Set(storageRESTVolume, "foo")
+	values.Set(storageRESTFilePath, "does-not-exist")
+	values.Set(storageRESTDiskID, "")
+	reqURL := "http://" + endpoint.Host + pathJoin(storageRESTPrefix, endpoint.Path) + storageRESTVersionPrefix + storageRESTMethodReadAll + "?" + values.Encode()
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+globalNodeAuthToken)
+	req.Header.Set("X-Minio-Time", strconv.FormatInt(time.Now().UnixNano(), 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		t.Fatal("expected an error response for a missing file")
+	}
+	dec, ok := storageErrDecode(string(body))
+	if !ok {
+		t.Fatalf("expected an envelope body, got %q", string(body))
+	}
+	if dec != errFileNotFound {
+		t.Fatalf("expected errFileNotFound envelope, got %v", dec)
+	}
+
+	// And through the regular client the sentinel surfaces untouched.
+	if _, err := restClient.ReadAll(context.Background(), "foo", "does-not-exist"); !errors.Is(err, errFileNotFound) {
+		t.Fatalf("expected errFileNotFound, got %v", err)
+	}
+}
+
+func TestStorageRESTClientDiskSnapshot(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	snap, err := restClient.DiskSnapshot(ctx, true, "foo", "bar", "snapshot-missing")
+	if err != nil {
+		t.Fatalf("DiskSnapshot failed: %v", err)
+	}
+
+	// Volume results are positional with the request.
+	if len(snap.Volumes) != 3 || len(snap.VolErrs) != 3 {
+		t.Fatalf("expected 3 positional volume results, got %d/%d", len(snap.Volumes), len(snap.VolErrs))
+	}
+	for i, volume := range []string{"foo", "bar"} {
+		if snap.VolErrs[i] != "" {
+			t.Fatalf("unexpected error for %q: %v", volume, snap.VolErrs[i])
+		}
+		if snap.Volumes[i].Name != volume {
+			t.Fatalf("expected VolInfo for %q at index %d, got %q", volume, i, snap.Volumes[i].Name)
+		}
+	}
+	if snap.VolErrs[2] != errVolumeNotFound.Error() {
+		t.Fatalf("expected volume not found for the missing volume, got %q", snap.VolErrs[2])
+	}
+
+	// The snapshot must describe the drive it was asked about.
+	if snap.Disk.Endpoint != restClient.endpoint.String() {
+		t.Fatalf("snapshot endpoint %q does not match client endpoint %q", snap.Disk.Endpoint, restClient.endpoint.String())
+	}
+	if snap.Disk.ID != *restClient.diskID.Load() {
+		t.Fatalf("snapshot disk ID %q does not match client disk ID %q", snap.Disk.ID, *restClient.diskID.Load())
+	}
+	vi, err := restClient.StatVol(ctx, "foo")
+	if err != nil {
+		t.Fatalf("StatVol failed: %v", err)
+	}
+	if !snap.Volumes[0].Created.Equal(vi.Created) {
+		t.Fatalf("snapshot VolInfo created %v does not match StatVol %v", snap.Volumes[0].Created, vi.Created)
+	}
+}
+
+func TestIsSecureStoragePath(t *testing.T) {
+	testCases := []struct {
+		path string
+		ok   bool
+	}{
+		// Legitimate paths, including dots inside names.
+		{"", true},
+		{"object", true},
+		{"dir/object", true},
+		{"file.tar.gz", true},
+		{"a..b/c..d", true},
+		{"dir/.hidden", true},
+		{".minio.sys/tmp", true},
+		// Traversal, absolute paths and null bytes.
+		{"..", false},
+		{"../etc/passwd", false},
+		{"dir/../../escape", false},
+		{"dir/..", false},
+		{"../", false},
+		{"/absolute/path", false},
+		{`\absolute`, false},
+		{`dir\..\escape`, false},
+		{"null\x00byte", false},
+		{"dir/\x00", false},
+	}
+	for _, testCase := range testCases {
+		if got := isSecureStoragePath(testCase.path); got != testCase.ok {
+			t.Errorf("isSecureStoragePath(%q) = %v, want %v", testCase.path, got, testCase.ok)
+		}
+	}
+}
+
+func TestStorageRESTPathTraversalRejected(t *testing.T) {
+	restClient := newStorageRESTHTTPServerClient(t)
+	ctx := context.Background()
+
+	// Grid write path.
+	if err := restClient.WriteAll(ctx, "foo", "../../escape", []byte("x")); !errors.Is(err, errInvalidArgument) {
+		t.Fatalf("WriteAll: expected errInvalidArgument, got %v", err)
+	}
+	// Grid rename path, both sides checked.
+	if err := restClient.RenameFile(ctx, "foo", "legit", "foo", "../escape"); !errors.Is(err, errInvalidArgument) {
+		t.Fatalf("RenameFile dst: expected errInvalidArgument, got %v", err)
+	}
+	if err := restClient.RenameFile(ctx, "foo", "../escape", "foo", "legit"); !errors.Is(err, errInvalidArgument) {
+		t.Fatalf("RenameFile src: expected errInvalidArgument, got %v", err)
+	}
+	// REST read path is rejected in IsValid before any disk access.
+	if _, err := restClient.ReadFileStream(ctx, "foo", "/etc/passwd", 0, 1); !errors.Is(err, errInvalidArgument) {
+		t.Fatalf("ReadFileStream: expected errInvalidArgument, got %v", err)
+	}
+	// Volume parameters are held to the same rule.
+	if err := restClient.WriteAll(ctx, "../foo", "object", []byte("x")); !errors.Is(err, errInvalidArgument) {
+		t.Fatalf("WriteAll volume: expected errInvalidArgument, got %v", err)
+	}
+
+	// Paths carried in msgp request bodies are held to the same rule
+	// as form parameters.
+	if _, err := restClient.StatParts(ctx, "foo", "../escape", []int{1}); !errors.Is(err, errInvalidArgument) {
+		t.Fatalf("StatParts: expected errInvalidArgument, got %v", err)
+	}
+	if _, err := restClient.MutateMetadata(ctx, "foo", "../escape", "", nil); !errors.Is(err, errInvalidArgument) {
+		t.Fatalf("MutateMetadata: expected errInvalidArgument, got %v", err)
+	}
+	_, errs := restClient.ReadVersions(ctx, []ReadVersionsEntry{{Volume: "foo", FilePath: "../escape"}})
+	if len(errs) != 1 || !errors.Is(errs[0], errInvalidArgument) {
+		t.Fatalf("ReadVersions: expected errInvalidArgument, got %v", errs)
+	}
+
+	// Dots inside a file name stay legal end to end.
+	if err := restClient.WriteAll(ctx, "foo", "dir/archive..v1.tar.gz", []byte("dots")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	buf, err := restClient.ReadAll(ctx, "foo", "dir/archive..v1.tar.gz")
+	if err != nil || string(buf) != "dots" {
+		t.Fatalf("expected dotted name round-trip, got %q err=%v", buf, err)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "sync"
+
+// bitrotErrorLogSize bounds the number of detections kept per drive,
+// older entries are dropped first.
+const bitrotErrorLogSize = 100
+
+// bitrotErrorLog keeps a bounded list of the most recent bitrot
+// detections on a drive, populated whenever part verification finds a
+// corrupted shard. It gives proactive corruption visibility without
+// waiting for a heal to be queued.
+type bitrotErrorLog struct {
+	mu      sync.Mutex
+	entries []BitrotErrorInfo
+}
+
+// add records a detection, dropping the oldest entry when full.
+func (l *bitrotErrorLog) add(info BitrotErrorInfo) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) >= bitrotErrorLogSize {
+		l.entries = l.entries[1:]
+	}
+	l.entries = append(l.entries, info)
+}
+
+// list returns a copy of the recorded detections, oldest first.
+func (l *bitrotErrorLog) list() []BitrotErrorInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]BitrotErrorInfo, len(l.entries))
+	copy(entries, l.entries)
+	return entries
+}
+
+var globalBitrotErrorLogs = newDriveRegistry(func() *bitrotErrorLog {
+	return &bitrotErrorLog{}
+})
+
+// bitrotErrorLogFor returns the log for the given drive endpoint,
+// creating it if necessary.
+func bitrotErrorLogFor(endpoint string) *bitrotErrorLog {
+	return globalBitrotErrorLogs.get(endpoint)
+}
@@ -268,6 +268,17 @@ func (p *xlStorageDiskIDCheck) SetDiskID(id string) {
 	p.diskID.Store(&id)
 }
 
+// RefreshDiskID forces a re-read of the backing drive's disk-id from
+// format.json and updates the cached copy used for staleness checks.
+func (p *xlStorageDiskIDCheck) RefreshDiskID() (string, error) {
+	id, err := p.storage.RefreshDiskID()
+	if err != nil {
+		return "", err
+	}
+	p.SetDiskID(id)
+	return id, nil
+}
+
 func (p *xlStorageDiskIDCheck) checkDiskStale() error {
 	if *p.diskID.Load() == emptyDiskID {
 		// For empty disk-id we allow the call as the server might be
@@ -434,6 +445,20 @@ func (p *xlStorageDiskIDCheck) AppendFile(ctx context.Context, volume string, pa
 	})
 }
 
+// Legacy API - does not have any deadlines
+func (p *xlStorageDiskIDCheck) AppendFileIfExists(ctx context.Context, volume string, path string, buf []byte) (err error) {
+	ctx, done, err := p.TrackDiskHealth(ctx, storageMetricAppendFile, volume, path)
+	if err != nil {
+		return err
+	}
+	defer done(int64(len(buf)), &err)
+
+	w := xioutil.NewDeadlineWorker(globalDriveConfig.GetMaxTimeout())
+	return w.Run(func() error {
+		return p.storage.AppendFileIfExists(ctx, volume, path, buf)
+	})
+}
+
 func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, origvolume, volume, path string, size int64, reader io.Reader) (err error) {
 	ctx, done, err := p.TrackDiskHealth(ctx, storageMetricCreateFile, volume, path)
 	if err != nil {
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// fsyncBatcher coalesces fsync requests on a drive into a single
+// drive-wide flush per configured interval. With a zero interval every
+// request syncs inline, which is the default behavior.
+//
+// Batching trades a small durability window (up to the configured
+// interval) for far fewer fsync calls on high-ingest workloads.
+type fsyncBatcher struct {
+	mu        sync.Mutex
+	interval  time.Duration
+	scheduled bool
+
+	// syncer performs the actual flush, replaceable for testing.
+	// When nil, globalSync is used.
+	syncer func()
+}
+
+// setInterval updates the batching window, a zero or negative
+// duration disables batching.
+func (b *fsyncBatcher) setInterval(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if d < 0 {
+		d = 0
+	}
+	b.interval = d
+}
+
+// getInterval returns the currently configured batching window.
+func (b *fsyncBatcher) getInterval() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.interval
+}
+
+// inline reports whether the caller should fsync inline. When batching
+// is enabled the fsync is folded into a single flush scheduled at the
+// end of the current window and false is returned.
+func (b *fsyncBatcher) inline() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.interval <= 0 {
+		return true
+	}
+	if !b.scheduled {
+		b.scheduled = true
+		time.AfterFunc(b.interval, b.flush)
+	}
+	return false
+}
+
+func (b *fsyncBatcher) flush() {
+	b.mu.Lock()
+	b.scheduled = false
+	syncer := b.syncer
+	b.mu.Unlock()
+	if syncer == nil {
+		globalSync()
+		return
+	}
+	syncer()
+}
+
+var globalFsyncBatchers = newDriveRegistry(func() *fsyncBatcher {
+	return &fsyncBatcher{}
+})
+
+// fsyncBatcherFor returns the batcher for the given drive endpoint,
+// creating it if necessary.
+func fsyncBatcherFor(endpoint string) *fsyncBatcher {
+	return globalFsyncBatchers.get(endpoint)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFsyncBatcher(t *testing.T) {
+	var synced atomic.Int64
+	b := &fsyncBatcher{syncer: func() { synced.Add(1) }}
+
+	// Without an interval every write syncs inline.
+	for i := 0; i < 3; i++ {
+		if !b.inline() {
+			t.Fatalf("write %d: expected inline fsync with batching disabled", i)
+		}
+	}
+	if n := synced.Load(); n != 0 {
+		t.Fatalf("expected no batched fsyncs, got %d", n)
+	}
+
+	// With an interval, all writes within the window share one flush.
+	b.setInterval(50 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		if b.inline() {
+			t.Fatalf("write %d: expected fsync to join the batched flush", i)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for synced.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := synced.Load(); n != 1 {
+		t.Fatalf("expected a single batched fsync, got %d", n)
+	}
+
+	// Disabling the policy restores inline syncs.
+	b.setInterval(0)
+	if !b.inline() {
+		t.Fatalf("expected inline fsync after disabling the policy")
+	}
+}
+
+func TestFsyncBatcherFor(t *testing.T) {
+	b := fsyncBatcherFor("test-drive-endpoint")
+	if got := fsyncBatcherFor("test-drive-endpoint"); got != b {
+		t.Fatalf("expected the same batcher for the same endpoint")
+	}
+	b.setInterval(time.Second)
+	if got := fsyncBatcherFor("test-drive-endpoint").getInterval(); got != time.Second {
+		t.Fatalf("expected configured interval to persist, got %v", got)
+	}
+	b.setInterval(0)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync/atomic"
+)
+
+// scanBudget caps how many objects a drive's scanner visits per cycle.
+// A limit of zero (the default) means unlimited; once the budget is
+// exhausted the remaining objects are skipped until the next cycle
+// resets the counter.
+type scanBudget struct {
+	limit int64 // maximum objects per cycle, accessed atomically
+	used  int64 // objects consumed this cycle, accessed atomically
+}
+
+func (b *scanBudget) setLimit(n int64) {
+	atomic.StoreInt64(&b.limit, n)
+}
+
+func (b *scanBudget) getLimit() int64 {
+	return atomic.LoadInt64(&b.limit)
+}
+
+// reset starts a new scanner cycle.
+func (b *scanBudget) reset() {
+	atomic.StoreInt64(&b.used, 0)
+}
+
+// consume reports whether another object may be scanned in this cycle.
+func (b *scanBudget) consume() bool {
+	limit := atomic.LoadInt64(&b.limit)
+	if limit <= 0 {
+		return true
+	}
+	return atomic.AddInt64(&b.used, 1) <= limit
+}
+
+var globalScanBudgets = newDriveRegistry(func() *scanBudget {
+	return &scanBudget{}
+})
+
+// scanBudgetFor returns the scan budget for the given endpoint,
+// creating it on first use.
+func scanBudgetFor(endpoint string) *scanBudget {
+	return globalScanBudgets.get(endpoint)
+}
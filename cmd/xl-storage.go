@@ -126,6 +126,15 @@ type xlStorage struct {
 	rotational bool
 	walkMu     *sync.Mutex
 	walkReadMu *sync.Mutex
+
+	// batches fsyncs when a batching policy is configured.
+	fsyncBatch *fsyncBatcher
+
+	// records recently detected bitrot errors on this drive.
+	bitrotLog *bitrotErrorLog
+
+	// caps how many objects the scanner visits per cycle.
+	scanBudget *scanBudget
 }
 
 // checkPathLength - returns error if given path name length more than 255
@@ -228,6 +237,9 @@ func newXLStorage(ep Endpoint, cleanUp bool) (s *xlStorage, err error) {
 		diskInfoCache:        cachevalue.New[DiskInfo](),
 		immediatePurge:       make(chan string, immediatePurgeQueue),
 		immediatePurgeCancel: cancel,
+		fsyncBatch:           fsyncBatcherFor(ep.String()),
+		bitrotLog:            bitrotErrorLogFor(ep.String()),
+		scanBudget:           scanBudgetFor(ep.String()),
 	}
 
 	defer func() {
@@ -578,6 +590,17 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 
 	cache.Info.updates = updates
 
+	// Each NSScanner invocation is one cycle for this drive's budget.
+	s.scanBudget.reset()
+
+	taskQueue := driveTaskQueueFor(s.endpoint.String())
+	taskQueue.enqueue(taskQueueScanner)
+	defer taskQueue.dequeue(taskQueueScanner)
+
+	// Roll a fresh metadata digest for this bucket as we scan, it is
+	// published only when the pass completes.
+	mdScan := &metaDigestScan{}
+
 	dataUsageInfo, err := scanDataFolder(ctx, disks, s, cache, func(item scannerItem) (sizeSummary, error) {
 		// Look for `xl.meta/xl.json' at the leaf.
 		if !strings.HasSuffix(item.Path, SlashSeparator+xlStorageFormatFile) &&
@@ -585,6 +608,11 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 			// if no xl.meta/xl.json found, skip the file.
 			return sizeSummary{}, errSkipFile
 		}
+		if !s.scanBudget.consume() {
+			// Per-cycle object budget exhausted, skip the rest of
+			// this cycle.
+			return sizeSummary{}, errSkipFile
+		}
 		stopFn := globalScannerMetrics.log(scannerMetricScanObject, s.drivePath, pathJoin(item.bucket, item.objectPath()))
 		res := make(map[string]string, 8)
 		defer func() {
@@ -597,8 +625,10 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 		res["metasize"] = strconv.Itoa(len(buf))
 		if err != nil {
 			res["err"] = err.Error()
+			scanErrorWindowFor(s.endpoint.String()).add()
 			return sizeSummary{}, errSkipFile
 		}
+		mdScan.fold(item.Path, buf)
 
 		// Remove filename which is the meta file.
 		item.transformMetaDir()
@@ -607,6 +637,7 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 		metaDataPoolPut(buf)
 		if err != nil {
 			res["err"] = err.Error()
+			scanErrorWindowFor(s.endpoint.String()).add()
 			return sizeSummary{}, errSkipFile
 		}
 
@@ -727,8 +758,10 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 		return sizeS, nil
 	}, scanMode, weSleep)
 	if err != nil {
+		scanErrorWindowFor(s.endpoint.String()).add()
 		return dataUsageInfo, err
 	}
+	driveMetaDigestFor(s.endpoint.String()).publish(cache.Info.Name, mdScan)
 
 	dataUsageInfo.Info.LastUpdate = time.Now()
 	return dataUsageInfo, nil
@@ -900,6 +933,18 @@ func (s *xlStorage) GetDiskID() (string, error) {
 	return diskID, nil
 }
 
+// RefreshDiskID invalidates the cached disk-id and re-reads it from
+// format.json. Useful after the drive has been re-formatted in place,
+// where the cached id would otherwise be served stale for a while.
+func (s *xlStorage) RefreshDiskID() (string, error) {
+	s.Lock()
+	s.diskID = ""
+	s.formatFileInfo = nil
+	s.formatLastCheck = time.Time{}
+	s.Unlock()
+	return s.GetDiskID()
+}
+
 // Make a volume entry.
 func (s *xlStorage) SetDiskID(id string) {
 	// NO-OP for xlStorage as it is handled either by xlStorageDiskIDCheck{} for local disks or
@@ -2247,6 +2292,11 @@ func (s *xlStorage) writeAllMeta(ctx context.Context, volume string, path string
 }
 
 func (s *xlStorage) writeAllInternal(ctx context.Context, filePath string, b []byte, sync bool, skipParent string) (err error) {
+	if sync && s.fsyncBatch != nil && !s.fsyncBatch.inline() {
+		// fsync is folded into the drive-wide batched flush.
+		sync = false
+	}
+
 	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
 
 	var w *os.File
@@ -2329,6 +2379,17 @@ func (s *xlStorage) WriteAll(ctx context.Context, volume string, path string, b
 // AppendFile - append a byte array at path, if file doesn't exist at
 // path this call explicitly creates it.
 func (s *xlStorage) AppendFile(ctx context.Context, volume string, path string, buf []byte) (err error) {
+	return s.appendFile(ctx, volume, path, buf, true)
+}
+
+// AppendFileIfExists - append a byte array at path, a missing file is
+// errFileNotFound instead of being created. The existence check is the
+// open itself, so there is no window for the file to vanish in between.
+func (s *xlStorage) AppendFileIfExists(ctx context.Context, volume string, path string, buf []byte) (err error) {
+	return s.appendFile(ctx, volume, path, buf, false)
+}
+
+func (s *xlStorage) appendFile(ctx context.Context, volume string, path string, buf []byte, create bool) (err error) {
 	volumeDir, err := s.getVolDir(volume)
 	if err != nil {
 		return err
@@ -2346,11 +2407,20 @@ func (s *xlStorage) AppendFile(ctx context.Context, volume string, path string,
 		return err
 	}
 
-	var w *os.File
-	// Create file if not found. Not doing O_DIRECT here to avoid the code that does buffer aligned writes.
+	// Not doing O_DIRECT here to avoid the code that does buffer aligned writes.
 	// AppendFile() is only used by healing code to heal objects written in old format.
-	w, err = s.openFileSync(filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, volumeDir)
+	flags := os.O_APPEND | os.O_WRONLY
+	if create {
+		// Create file if not found.
+		flags |= os.O_CREATE
+	}
+
+	var w *os.File
+	w, err = s.openFileSync(filePath, flags, volumeDir)
 	if err != nil {
+		if !create && osIsNotExist(err) {
+			return errFileNotFound
+		}
 		return err
 	}
 	defer w.Close()
@@ -3093,6 +3163,20 @@ func (s *xlStorage) bitrotVerify(ctx context.Context, partPath string, partSize
 	return bitrotVerify(diskHealthReader(ctx, file), fi.Size(), partSize, algo, sum, shardSize)
 }
 
+// verifyFileProgressFn receives per-part progress while VerifyFile
+// runs: the index of the part just verified and the cumulative shard
+// bytes verified so far.
+type verifyFileProgressFn func(part int, bytesDone int64)
+
+type verifyFileProgressKey struct{}
+
+// withVerifyFileProgress threads a progress callback through to
+// VerifyFile via the context, so the StorageAPI surface stays
+// unchanged for callers that do not care about progress.
+func withVerifyFileProgress(ctx context.Context, fn verifyFileProgressFn) context.Context {
+	return context.WithValue(ctx, verifyFileProgressKey{}, fn)
+}
+
 func (s *xlStorage) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (*CheckPartsResp, error) {
 	volumeDir, err := s.getVolDir(volume)
 	if err != nil {
@@ -3111,6 +3195,9 @@ func (s *xlStorage) VerifyFile(ctx context.Context, volume, path string, fi File
 		Results: make([]int, len(fi.Parts)),
 	}
 
+	progress, _ := ctx.Value(verifyFileProgressKey{}).(verifyFileProgressFn)
+	var bytesDone int64
+
 	erasure := fi.Erasure
 	for i, part := range fi.Parts {
 		checksumInfo := erasure.GetChecksumInfo(part.Number)
@@ -3122,11 +3209,25 @@ func (s *xlStorage) VerifyFile(ctx context.Context, volume, path string, fi File
 
 		resp.Results[i] = convPartErrToInt(err)
 
+		if resp.Results[i] == checkPartFileCorrupt && s.bitrotLog != nil {
+			s.bitrotLog.add(BitrotErrorInfo{
+				Volume:   volume,
+				Path:     path,
+				Part:     part.Number,
+				Detected: UTCNow(),
+			})
+		}
+
 		// Only log unknown errors
 		if resp.Results[i] == checkPartUnknown && err != errFileAccessDenied {
 			logger.GetReqInfo(ctx).AppendTags("disk", s.String())
 			storageLogOnceIf(ctx, err, partPath)
 		}
+
+		if progress != nil {
+			bytesDone += erasure.ShardFileSize(part.Size)
+			progress(i, bytesDone)
+		}
 	}
 
 	return &resp, nil
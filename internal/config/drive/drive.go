@@ -18,6 +18,7 @@
 package drive
 
 import (
+	"strconv"
 	"sync"
 	"time"
 
@@ -30,6 +31,7 @@ const (
 	EnvMaxDriveTimeout       = "MINIO_DRIVE_MAX_TIMEOUT"
 	EnvMaxDriveTimeoutLegacy = "_MINIO_DRIVE_MAX_TIMEOUT"
 	EnvMaxDiskTimeoutLegacy  = "_MINIO_DISK_MAX_TIMEOUT"
+	EnvMaxDriveHeavyOps      = "MINIO_DRIVE_MAX_HEAVY_OPS"
 )
 
 // DefaultKVS - default KVS for drive
@@ -38,6 +40,10 @@ var DefaultKVS = config.KVS{
 		Key:   MaxTimeout,
 		Value: "30s",
 	},
+	config.KV{
+		Key:   MaxHeavyOps,
+		Value: "0",
+	},
 }
 
 var configLk sync.RWMutex
@@ -46,6 +52,10 @@ var configLk sync.RWMutex
 type Config struct {
 	// MaxTimeout - maximum timeout for a drive operation
 	MaxTimeout time.Duration `json:"maxTimeout"`
+
+	// MaxHeavyOps - maximum concurrent heavy operations one drive
+	// serves, 0 picks a default scaled to the drive count.
+	MaxHeavyOps int `json:"maxHeavyOps"`
 }
 
 // Update - updates the config with latest values
@@ -53,6 +63,7 @@ func (c *Config) Update(new Config) error {
 	configLk.Lock()
 	defer configLk.Unlock()
 	c.MaxTimeout = getMaxTimeout(new.MaxTimeout)
+	c.MaxHeavyOps = new.MaxHeavyOps
 	return nil
 }
 
@@ -69,6 +80,15 @@ func (c *Config) GetOPTimeout() time.Duration {
 	return getMaxTimeout(c.MaxTimeout)
 }
 
+// GetMaxHeavyOps - returns the per drive concurrent heavy operations
+// limit, 0 when the default should be derived from the drive count.
+func (c *Config) GetMaxHeavyOps() int {
+	configLk.RLock()
+	defer configLk.RUnlock()
+
+	return c.MaxHeavyOps
+}
+
 // LookupConfig - lookup config and override with valid environment settings if any.
 func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	cfg = Config{
@@ -90,6 +110,9 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 			cfg.MaxTimeout = getMaxTimeout(dur)
 		}
 	}
+	if n, _ := strconv.Atoi(env.Get(EnvMaxDriveHeavyOps, kvs.GetWithDefault(MaxHeavyOps, DefaultKVS))); n > 0 {
+		cfg.MaxHeavyOps = n
+	}
 	return cfg, err
 }
 
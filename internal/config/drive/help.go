@@ -23,6 +23,9 @@ var (
 	// MaxTimeout is the max timeout for drive
 	MaxTimeout = "max_timeout"
 
+	// MaxHeavyOps is the per drive concurrent heavy operations limit
+	MaxHeavyOps = "max_heavy_ops"
+
 	// HelpDrive is help for drive
 	HelpDrive = config.HelpKVS{
 		config.HelpKV{
@@ -31,5 +34,11 @@ var (
 			Description: "set per call max_timeout for the drive, defaults to 30 seconds",
 			Optional:    true,
 		},
+		config.HelpKV{
+			Key:         MaxHeavyOps,
+			Type:        "number",
+			Description: "set the number of concurrent heavy operations one drive serves, defaults to a value scaled to the drive count",
+			Optional:    true,
+		},
 	}
 )
@@ -115,6 +115,11 @@ const (
 	HandlerCheckParts2
 	HandlerRenamePart
 	HandlerClearUploadID
+	HandlerReadVersions
+	HandlerStatParts
+	HandlerListDirBudget
+	HandlerListDirChunked
+	HandlerDiskSnapshot
 
 	// Add more above here ^^^
 	// If all handlers are used, the type of Handler can be changed.
@@ -139,6 +144,11 @@ var handlerPrefixes = [handlerLast]string{
 	HandlerNSScanner:                   storagePrefix,
 	HandlerReadXL:                      storagePrefix,
 	HandlerReadVersion:                 storagePrefix,
+	HandlerReadVersions:                storagePrefix,
+	HandlerStatParts:                   storagePrefix,
+	HandlerListDirBudget:               storagePrefix,
+	HandlerListDirChunked:              storagePrefix,
+	HandlerDiskSnapshot:                storagePrefix,
 	HandlerDeleteFile:                  storagePrefix,
 	HandlerDeleteVersion:               storagePrefix,
 	HandlerUpdateMetadata:              storagePrefix,
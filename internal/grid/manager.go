@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"runtime/debug"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gobwas/ws"
@@ -71,6 +72,10 @@ type Manager struct {
 
 	// routePath indicates the dial route path
 	routePath string
+
+	// registered tracks handler registrations by name for diagnostics.
+	registeredMu sync.Mutex
+	registered   map[string][]string
 }
 
 // ManagerOptions are options for creating a new grid manager.
@@ -102,11 +107,12 @@ func NewManager(ctx context.Context, o ManagerOptions) (*Manager, error) {
 		return nil, fmt.Errorf("grid: AuthFn not set")
 	}
 	m := &Manager{
-		ID:        uuid.New(),
-		targets:   make(map[string]*Connection, len(o.Hosts)),
-		local:     o.Local,
-		authToken: o.AuthToken,
-		routePath: o.RoutePath,
+		ID:         uuid.New(),
+		targets:    make(map[string]*Connection, len(o.Hosts)),
+		local:      o.Local,
+		authToken:  o.AuthToken,
+		routePath:  o.RoutePath,
+		registered: make(map[string][]string),
 	}
 	m.handlers.init()
 	if ctx == nil {
@@ -294,6 +300,7 @@ func (m *Manager) RegisterSingleHandler(id HandlerID, h SingleHandlerFn, subrout
 		}
 
 		m.handlers.single[id] = h
+		m.recordRegistration(id, "")
 		return nil
 	}
 	subID := makeSubHandlerID(id, s)
@@ -303,9 +310,31 @@ func (m *Manager) RegisterSingleHandler(id HandlerID, h SingleHandlerFn, subrout
 	m.handlers.subSingle[subID] = h
 	// Copy so clients can also pick it up for other subpaths.
 	m.handlers.subSingle[makeZeroSubHandlerID(id)] = h
+	m.recordRegistration(id, s)
 	return nil
 }
 
+// recordRegistration remembers a successful handler registration so it
+// can be reported by RegisteredHandlers.
+func (m *Manager) recordRegistration(id HandlerID, subroute string) {
+	m.registeredMu.Lock()
+	defer m.registeredMu.Unlock()
+	m.registered[id.String()] = append(m.registered[id.String()], subroute)
+}
+
+// RegisteredHandlers returns the names of all handlers registered on
+// this manager mapped to their subroutes. A handler registered without
+// a subroute is reported with a single empty subroute entry.
+func (m *Manager) RegisteredHandlers() map[string][]string {
+	m.registeredMu.Lock()
+	defer m.registeredMu.Unlock()
+	registered := make(map[string][]string, len(m.registered))
+	for name, subroutes := range m.registered {
+		registered[name] = append([]string(nil), subroutes...)
+	}
+	return registered
+}
+
 /*
 // RegisterStateless will register a stateless handler that serves
 // []byte -> stream of ([]byte, error) requests.
@@ -336,6 +365,7 @@ func (m *Manager) RegisterStreamingHandler(id HandlerID, h StreamHandler) error
 			return ErrHandlerAlreadyExists
 		}
 		m.handlers.streams[id] = &h
+		m.recordRegistration(id, "")
 		return nil
 	}
 	subID := makeSubHandlerID(id, h.Subroute)
@@ -345,6 +375,7 @@ func (m *Manager) RegisterStreamingHandler(id HandlerID, h StreamHandler) error
 	m.handlers.subStreams[subID] = &h
 	// Copy so clients can also pick it up for other subpaths.
 	m.handlers.subStreams[makeZeroSubHandlerID(id)] = &h
+	m.recordRegistration(id, h.Subroute)
 	return nil
 }
 
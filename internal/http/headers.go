@@ -166,6 +166,21 @@ const (
 	// Peer call
 	MinIOPeerCall = "x-minio-from-peer"
 
+	// Absolute deadline of the calling request on internode calls,
+	// unix-nanos or RFC3339.
+	MinIODeadline = "X-Minio-Deadline"
+
+	// Payload codecs the caller can decode on internode calls,
+	// e.g. "s2".
+	MinIOAcceptEncoding = "X-Minio-Accept-Encoding"
+
+	// Bitrot algorithm the server should hash a streamed read with on
+	// internode calls, the digest arrives in the trailer below.
+	MinIOReadHashAlgo = "X-Minio-Read-Hash-Algo"
+
+	// Trailer carrying the hex digest of the streamed bytes.
+	MinIOReadHashSum = "X-Minio-Read-Hash-Sum"
+
 	// Server-Status
 	MinIOServerStatus = "x-minio-server-status"
 
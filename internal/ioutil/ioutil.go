@@ -316,6 +316,59 @@ func Copy(dst io.Writer, src io.Reader) (written int64, err error) {
 	return io.CopyBuffer(writerOnly{dst}, src, buf)
 }
 
+// copyAdaptiveGrowAfter is how many buffers worth of data must be
+// copied at the current size class before the copy buffer grows to
+// the next one.
+const copyAdaptiveGrowAfter = 4
+
+// CopyAdaptive is like Copy but sizes the buffer to the transfer: it
+// starts with a small pooled buffer and grows through the medium and
+// large size classes once the transfer proves long-running, so short
+// reads stay cheap while bulk streams get larger sequential I/O.
+func CopyAdaptive(dst io.Writer, src io.Reader) (written int64, err error) {
+	classes := []struct {
+		pool *sync.Pool
+		size int64
+	}{
+		{&ODirectPoolSmall, SmallBlock},
+		{&ODirectPoolMedium, MediumBlock},
+		{&ODirectPoolLarge, LargeBlock},
+	}
+	class := 0
+	bufp := classes[class].pool.Get().(*[]byte)
+	defer func() {
+		classes[class].pool.Put(bufp)
+	}()
+
+	for {
+		buf := *bufp
+		nr, rerr := src.Read(buf)
+		if nr > 0 {
+			nw, werr := dst.Write(buf[:nr])
+			written += int64(nw)
+			if werr != nil {
+				return written, werr
+			}
+			if nw != nr {
+				return written, io.ErrShortWrite
+			}
+		}
+		if rerr != nil {
+			if rerr == io.EOF {
+				rerr = nil
+			}
+			return written, rerr
+		}
+		// Grow to the next size class once the source has kept the
+		// current buffer full for a few reads in a row.
+		if class < len(classes)-1 && nr == len(buf) && written >= copyAdaptiveGrowAfter*classes[class].size {
+			classes[class].pool.Put(bufp)
+			class++
+			bufp = classes[class].pool.Get().(*[]byte)
+		}
+	}
+}
+
 // SameFile returns if the files are same.
 func SameFile(fi1, fi2 os.FileInfo) bool {
 	if !os.SameFile(fi1, fi2) {
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -224,3 +225,44 @@ func TestCopyAligned(t *testing.T) {
 		t.Errorf("Expected written to be '%v', but got %v", r.Size(), written)
 	}
 }
+
+func TestCopyAdaptive(t *testing.T) {
+	for _, size := range []int{0, 1, SmallBlock, 3 * MediumBlock, 10*LargeBlock + 123} {
+		src := bytes.Repeat([]byte{'a'}, size)
+		var dst bytes.Buffer
+		n, err := CopyAdaptive(&dst, bytes.NewReader(src))
+		if err != nil {
+			t.Fatalf("size %d: unexpected error %v", size, err)
+		}
+		if n != int64(size) || !bytes.Equal(dst.Bytes(), src) {
+			t.Fatalf("size %d: copied %d bytes, content match: %v", size, n, bytes.Equal(dst.Bytes(), src))
+		}
+	}
+}
+
+func benchmarkCopy(b *testing.B, copyFn func(io.Writer, io.Reader) (int64, error), size int64) {
+	src := bytes.Repeat([]byte{'a'}, int(size))
+	b.SetBytes(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := copyFn(io.Discard, bytes.NewReader(src)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCopy(b *testing.B) {
+	for _, size := range []int64{4 << 10, 64 << 20} {
+		b.Run(strconv.FormatInt(size, 10), func(b *testing.B) {
+			benchmarkCopy(b, Copy, size)
+		})
+	}
+}
+
+func BenchmarkCopyAdaptive(b *testing.B) {
+	for _, size := range []int64{4 << 10, 64 << 20} {
+		b.Run(strconv.FormatInt(size, 10), func(b *testing.B) {
+			benchmarkCopy(b, CopyAdaptive, size)
+		})
+	}
+}
@@ -193,6 +193,9 @@ func (c *Client) newRequest(ctx context.Context, method string, u url.URL, body
 		req.Header.Set("Authorization", "Bearer "+c.auth())
 	}
 	req.Header.Set("X-Minio-Time", strconv.FormatInt(time.Now().UnixNano(), 10))
+	if deadline, ok := ctx.Deadline(); ok {
+		req.Header.Set(xhttp.MinIODeadline, strconv.FormatInt(deadline.UnixNano(), 10))
+	}
 
 	if tc, ok := ctx.Value(mcontext.ContextTraceKey).(*mcontext.TraceCtxt); ok {
 		req.Header.Set(xhttp.AmzRequestID, tc.AmzReqID)
@@ -292,6 +295,37 @@ var ErrClientClosed = errors.New("rest client is closed")
 
 // CallWithHTTPMethod - make a REST call with context, using a custom HTTP method.
 func (c *Client) CallWithHTTPMethod(ctx context.Context, httpMethod, rpcMethod string, values url.Values, body io.Reader, length int64) (reply io.ReadCloser, err error) {
+	reply, _, err = c.CallWithHeaders(ctx, httpMethod, rpcMethod, values, body, length, nil)
+	return reply, err
+}
+
+// CallWithHeaders is like CallWithHTTPMethod, but adds the given
+// headers to the request and returns the response headers along with
+// the body, for callers that negotiate behavior over headers.
+func (c *Client) CallWithHeaders(ctx context.Context, httpMethod, rpcMethod string, values url.Values, body io.Reader, length int64, header http.Header) (reply io.ReadCloser, respHeader http.Header, err error) {
+	resp, err := c.callResp(ctx, httpMethod, rpcMethod, values, body, length, header)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Body, resp.Header, nil
+}
+
+// CallWithTrailer is like CallWithHeaders, but the header returned is
+// the response trailer map, which the transport fills in once the body
+// has been read to EOF. Callers must drain the body before consulting
+// trailer values.
+func (c *Client) CallWithTrailer(ctx context.Context, httpMethod, rpcMethod string, values url.Values, body io.Reader, length int64, header http.Header) (reply io.ReadCloser, trailer http.Header, err error) {
+	resp, err := c.callResp(ctx, httpMethod, rpcMethod, values, body, length, header)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.Trailer == nil {
+		resp.Trailer = make(http.Header)
+	}
+	return resp.Body, resp.Trailer, nil
+}
+
+func (c *Client) callResp(ctx context.Context, httpMethod, rpcMethod string, values url.Values, body io.Reader, length int64, header http.Header) (*http.Response, error) {
 	switch atomic.LoadInt32(&c.connected) {
 	case closed:
 		// client closed, this is usually a manual process
@@ -314,6 +348,9 @@ func (c *Client) CallWithHTTPMethod(ctx context.Context, httpMethod, rpcMethod s
 	if err != nil {
 		return nil, &NetworkError{Err: err}
 	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
 	if length > 0 {
 		req.ContentLength = length
 	}
@@ -379,7 +416,7 @@ func (c *Client) CallWithHTTPMethod(ctx context.Context, httpMethod, rpcMethod s
 	if !c.NoMetrics {
 		resp.Body = &respBodyMonitor{ReadCloser: resp.Body, expectTimeouts: expectTimeouts}
 	}
-	return resp.Body, nil
+	return resp, nil
 }
 
 // Call - make a REST call with context.